@@ -11,6 +11,8 @@ import (
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/figment-networks/mina-indexer/client/archive"
+	"github.com/figment-networks/mina-indexer/client/graph"
 	"github.com/figment-networks/mina-indexer/config"
 	"github.com/figment-networks/mina-indexer/store"
 )
@@ -20,10 +22,12 @@ func Run() {
 	var configPath string
 	var runCommand string
 	var showVersion bool
+	var skipMigrations bool
 
 	flag.BoolVar(&showVersion, "v", false, "Show application version")
 	flag.StringVar(&configPath, "config", "", "Path to config")
 	flag.StringVar(&runCommand, "cmd", "", "Command to run")
+	flag.BoolVar(&skipMigrations, "skip-migrations", false, "Do not apply pending database migrations on startup")
 	flag.Parse()
 
 	if showVersion {
@@ -47,6 +51,12 @@ func Run() {
 		terminate("Command is required")
 	}
 
+	if !skipMigrations && migratesOnStartup(runCommand) {
+		if err := autoMigrate(cfg); err != nil {
+			terminate(err)
+		}
+	}
+
 	if err := startCommand(cfg, runCommand); err != nil {
 		terminate(err)
 	}
@@ -64,15 +74,29 @@ func startCommand(cfg *config.Config, name string) error {
 		return startWorker(cfg)
 	case "sync":
 		return runSync(cfg)
+	case "sync:delta":
+		return runDeltaSync(cfg)
 	case "status":
 		return startStatus(cfg)
 	case "update-identity":
 		return runUpdateIdentity(cfg)
+	case "print-config":
+		return printConfig(cfg)
 	default:
 		return fmt.Errorf("%s is not a valid command", name)
 	}
 }
 
+// migratesOnStartup returns true if the command needs an up to date database schema
+func migratesOnStartup(name string) bool {
+	switch name {
+	case "init", "server", "worker", "sync", "sync:delta":
+		return true
+	default:
+		return false
+	}
+}
+
 func terminate(message interface{}) {
 	if message != nil {
 		log.Fatal("ERROR: ", message)
@@ -86,10 +110,14 @@ func initConfig(path string) (*config.Config, error) {
 		return nil, err
 	}
 
+	// Environment variables take precedence over the config file
 	if path != "" {
 		if err := config.FromFile(path, cfg); err != nil {
 			return nil, err
 		}
+		if err := config.OverrideFromEnv(cfg); err != nil {
+			return nil, err
+		}
 	}
 
 	if err := cfg.Validate(); err != nil {
@@ -132,15 +160,51 @@ func initLog(cfg *config.Config) error {
 }
 
 func initStore(cfg *config.Config) (*store.Store, error) {
-	db, err := store.New(cfg.DatabaseURL)
+	return initStoreWithReplica(cfg, "")
+}
+
+// initStoreWithReplica returns a store that sends the read-only queries to the replica database
+func initStoreWithReplica(cfg *config.Config, replicaURL string) (*store.Store, error) {
+	db, err := store.NewWithReplica(cfg.DatabaseURL, replicaURL)
 	if err != nil {
 		return nil, err
 	}
 	db.SetDebugMode(cfg.LogLevel == "debug")
+	db.SetPoolSize(cfg.MaxOpenConns, cfg.MaxIdleConns)
+	db.SetSlowQueryThreshold(cfg.SlowQueryDuration())
+	db.SetCaseInsensitiveLookup(cfg.CaseInsensitiveLookup)
 
 	return db, nil
 }
 
+func initArchiveClient(cfg *config.Config) (archive.Client, error) {
+	client, err := archive.NewClient(cfg.ArchiveNodeType, cfg.ArchiveEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.MaxRetries > 0 {
+		client = archive.NewRetryClient(client, cfg.MaxRetries, cfg.InitialBackoffDuration())
+	}
+
+	return client, nil
+}
+
+// initGraphClient returns a graph client for the configured endpoints and connection pool settings
+func initGraphClient(cfg *config.Config) *graph.Client {
+	opts := graph.DefaultClientOptions
+	if cfg.GraphQL.MaxIdleConns > 0 {
+		opts.MaxIdleConns = cfg.GraphQL.MaxIdleConns
+	}
+	if d := cfg.GraphQL.IdleConnTimeoutDuration(); d > 0 {
+		opts.IdleConnTimeout = d
+	}
+	if d := cfg.GraphQL.DialTimeoutDuration(); d > 0 {
+		opts.DialTimeout = d
+	}
+
+	return graph.NewClient(cfg.GraphEndpoints(), opts)
+}
+
 func initSignals() chan os.Signal {
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, os.Kill, syscall.SIGTERM)
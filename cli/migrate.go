@@ -9,6 +9,7 @@ import (
 	"github.com/pressly/goose"
 
 	"github.com/figment-networks/mina-indexer/config"
+	"github.com/figment-networks/mina-indexer/store"
 	"github.com/figment-networks/mina-indexer/store/migrations"
 )
 
@@ -19,23 +20,12 @@ func startMigrations(cmd string, cfg *config.Config) error {
 	}
 	defer store.Close()
 
-	tmpDir, err := ioutil.TempDir("", "")
+	tmpDir, err := extractMigrations()
 	if err != nil {
 		return err
 	}
 	defer os.RemoveAll(tmpDir)
 
-	for path, f := range migrations.Assets.Files {
-		if filepath.Ext(path) != ".sql" {
-			continue
-		}
-
-		extPath := filepath.Join(tmpDir, filepath.Base(path))
-		if err := ioutil.WriteFile(extPath, f.Data, 0755); err != nil {
-			return err
-		}
-	}
-
 	dir := "up"
 	if chunks := strings.Split(cmd, ":"); len(chunks) > 1 {
 		dir = chunks[1]
@@ -55,3 +45,42 @@ func startMigrations(cmd string, cfg *config.Config) error {
 
 	return err
 }
+
+// autoMigrate applies the pending migrations before a command starts
+func autoMigrate(cfg *config.Config) error {
+	db, err := initStore(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	tmpDir, err := extractMigrations()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	return store.Migrate(db.Conn(), tmpDir)
+}
+
+// extractMigrations writes the embedded migration files into a temporary directory
+func extractMigrations() (string, error) {
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		return "", err
+	}
+
+	for path, f := range migrations.Assets.Files {
+		if filepath.Ext(path) != ".sql" {
+			continue
+		}
+
+		extPath := filepath.Join(tmpDir, filepath.Base(path))
+		if err := ioutil.WriteFile(extPath, f.Data, 0755); err != nil {
+			os.RemoveAll(tmpDir)
+			return "", err
+		}
+	}
+
+	return tmpDir, nil
+}
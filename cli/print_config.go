@@ -0,0 +1,19 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/figment-networks/mina-indexer/config"
+)
+
+// printConfig prints the effective configuration with the credentials redacted
+func printConfig(cfg *config.Config) error {
+	data, err := json.MarshalIndent(cfg.Redacted(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(data))
+	return nil
+}
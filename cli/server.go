@@ -1,7 +1,13 @@
 package cli
 
 import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
 	"log"
+	"os"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
@@ -12,12 +18,62 @@ import (
 func startServer(cfg *config.Config) error {
 	server.SetGinDefaults(cfg)
 
-	db, err := initStore(cfg)
+	// Only the API reads from the replica, the workers need to see their own writes
+	db, err := initStoreWithReplica(cfg, cfg.ReadReplicaDSN)
 	if err != nil {
 		return err
 	}
 	defer db.Close()
 
+	srv := server.New(db, initGraphClient(cfg), cfg, logrus.StandardLogger())
+
+	if cfg.ArchiveEndpoint != "" {
+		archiveClient, err := initArchiveClient(cfg)
+		if err != nil {
+			return err
+		}
+		srv.SetArchiveClient(archiveClient)
+	}
+
+	if cfg.TLSEnabled() {
+		expiresAt, err := checkCertificate(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if err != nil {
+			return err
+		}
+		logrus.WithField("expires_at", expiresAt).Info("using tls certificate")
+
+		log.Println("Starting TLS server on", cfg.ListenAddr())
+		return srv.RunTLS(cfg.ListenAddr(), cfg.TLSCertFile, cfg.TLSKeyFile)
+	}
+
 	log.Println("Starting server on", cfg.ListenAddr())
-	return server.New(db, cfg, logrus.StandardLogger()).Run(cfg.ListenAddr())
+	return srv.Run(cfg.ListenAddr())
+}
+
+// checkCertificate validates the TLS files and returns the certificate expiration time
+func checkCertificate(certFile string, keyFile string) (*time.Time, error) {
+	if _, err := os.Stat(keyFile); err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("tls certificate is not PEM encoded")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if time.Now().After(cert.NotAfter) {
+		return nil, errors.New("tls certificate expired at " + cert.NotAfter.String())
+	}
+
+	return &cert.NotAfter, nil
 }
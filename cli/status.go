@@ -3,7 +3,6 @@ package cli
 import (
 	"context"
 	"fmt"
-	"net/http"
 
 	"github.com/figment-networks/mina-indexer/client/graph"
 	"github.com/figment-networks/mina-indexer/config"
@@ -16,7 +15,7 @@ func startStatus(cfg *config.Config) error {
 	}
 	defer db.Close()
 
-	client := graph.NewClient(http.DefaultClient, cfg.MinaEndpoint)
+	client := initGraphClient(cfg)
 	status, err := client.GetDaemonStatus(context.Background())
 	if err != nil {
 		return err
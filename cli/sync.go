@@ -1,9 +1,10 @@
 package cli
 
 import (
-	"github.com/figment-networks/mina-indexer/client/archive"
-	"github.com/figment-networks/mina-indexer/client/graph"
+	"context"
+
 	"github.com/figment-networks/mina-indexer/config"
+	"github.com/figment-networks/mina-indexer/indexing"
 	"github.com/figment-networks/mina-indexer/worker"
 )
 
@@ -14,12 +15,44 @@ func runSync(cfg *config.Config) error {
 	}
 	defer db.Close()
 
-	archiveClient := archive.NewDefaultClient(cfg.ArchiveEndpoint)
-	graphClient := graph.NewDefaultClient(cfg.MinaEndpoint)
+	if err := worker.CheckSyncFromHeight(db, cfg.SyncFromHeight); err != nil {
+		return err
+	}
+
+	archiveClient, err := initArchiveClient(cfg)
+	if err != nil {
+		return err
+	}
+	graphClient := initGraphClient(cfg)
 	graphClient.SetDebug(cfg.LogLevel == "debug")
 
 	syncWorker := worker.NewSyncWorker(cfg, db, graphClient, archiveClient)
 
-	_, err = syncWorker.Run()
+	_, err = syncWorker.Run(context.Background())
 	return err
 }
+
+func runDeltaSync(cfg *config.Config) error {
+	db, err := initStore(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	archiveClient, err := initArchiveClient(cfg)
+	if err != nil {
+		return err
+	}
+	graphClient := initGraphClient(cfg)
+
+	first, err := db.Blocks.FirstBlock()
+	if err != nil {
+		return err
+	}
+	last, err := db.Blocks.Recent()
+	if err != nil {
+		return err
+	}
+
+	return indexing.DeltaSync(db, archiveClient, graphClient, first.Height, last.Height, cfg.DeltaSyncBatchSize, cfg.PrepareWorkers)
+}
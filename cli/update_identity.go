@@ -4,7 +4,9 @@ import (
 	"encoding/csv"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 
@@ -19,8 +21,8 @@ type identity struct {
 }
 
 func runUpdateIdentity(cfg *config.Config) error {
-	if cfg.IdentityFile == "" {
-		return errors.New("identity file is not provided")
+	if cfg.IdentityFile == "" && cfg.IdentityURL == "" {
+		return errors.New("identity file or url is not provided")
 	}
 
 	db, err := store.New(cfg.DatabaseURL)
@@ -31,7 +33,7 @@ func runUpdateIdentity(cfg *config.Config) error {
 
 	db.SetDebugMode(true)
 
-	return readIdentityFile(cfg.IdentityFile, func(item identity) error {
+	handler := func(item identity) error {
 		err := db.Validators.UpdateIdentity(item.PublicKey, item.Name)
 
 		logrus.
@@ -41,7 +43,36 @@ func runUpdateIdentity(cfg *config.Config) error {
 			Info("identity updated")
 
 		return err
-	})
+	}
+
+	if cfg.IdentityURL != "" {
+		return readIdentityURL(cfg.IdentityURL, handler)
+	}
+	return readIdentityFile(cfg.IdentityFile, handler)
+}
+
+func readIdentityURL(src string, handler func(identity) error) error {
+	resp, err := http.Get(src)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("identity registry returned status %d", resp.StatusCode)
+	}
+
+	identities := []identity{}
+	if err := json.NewDecoder(resp.Body).Decode(&identities); err != nil {
+		return err
+	}
+	for _, identityItem := range identities {
+		if err := handler(identityItem); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func readIdentityFile(src string, handler func(identity) error) error {
@@ -2,22 +2,23 @@ package cli
 
 import (
 	"context"
+	"net/http"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/figment-networks/mina-indexer/client/archive"
-	"github.com/figment-networks/mina-indexer/client/graph"
 	"github.com/figment-networks/mina-indexer/config"
 	"github.com/figment-networks/mina-indexer/store"
 	"github.com/figment-networks/mina-indexer/worker"
 )
 
-func startSyncWorker(wg *sync.WaitGroup, cfg *config.Config, db *store.Store) context.CancelFunc {
+func startSyncWorker(wg *sync.WaitGroup, cfg *config.Config, db *store.Store, archiveClient archive.Client) context.CancelFunc {
 	ctx, cancel := context.WithCancel(context.Background())
-	client := graph.NewDefaultClient(cfg.MinaEndpoint)
-	archiveClient := archive.NewDefaultClient(cfg.ArchiveEndpoint)
+	client := initGraphClient(cfg)
 	syncWorker := worker.NewSyncWorker(cfg, db, client, archiveClient)
 	timer := time.NewTimer(cfg.SyncDuration())
 
@@ -32,7 +33,7 @@ func startSyncWorker(wg *sync.WaitGroup, cfg *config.Config, db *store.Store) co
 		for {
 			select {
 			case <-timer.C:
-				lag, err := syncWorker.Run()
+				lag, err := syncWorker.Run(ctx)
 				if err != nil {
 					log.WithError(err).Error("sync failed")
 				}
@@ -74,9 +75,23 @@ func startCleanupWorker(wg *sync.WaitGroup, cfg *config.Config, db *store.Store)
 	return cancel
 }
 
+func startMetricsServer(addr string) {
+	log.Info("serving worker metrics on: ", addr)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	go func() {
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			log.WithError(err).Error("metrics server failed")
+		}
+	}()
+}
+
 func startWorker(cfg *config.Config) error {
-	log.Info("using mina graph endpoint: ", cfg.MinaEndpoint)
+	log.Info("using mina graph endpoints: ", strings.Join(cfg.GraphEndpoints(), ", "))
 	log.Info("using mina archive endpoint: ", cfg.ArchiveEndpoint)
+	log.Info("using mina archive node type: ", cfg.ArchiveNodeType)
 	log.Info("sync will run every: ", cfg.SyncInterval)
 	log.Info("cleanup will run every: ", cfg.CleanupInterval)
 
@@ -86,9 +101,22 @@ func startWorker(cfg *config.Config) error {
 	}
 	defer db.Close()
 
+	if err := worker.CheckSyncFromHeight(db, cfg.SyncFromHeight); err != nil {
+		return err
+	}
+
+	archiveClient, err := initArchiveClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	if cfg.MetricsAddr != "" {
+		startMetricsServer(cfg.MetricsAddr)
+	}
+
 	wg := &sync.WaitGroup{}
 
-	cancelSync := startSyncWorker(wg, cfg, db)
+	cancelSync := startSyncWorker(wg, cfg, db, archiveClient)
 	cancelCleanup := startCleanupWorker(wg, cfg, db)
 
 	s := <-initSignals()
@@ -2,36 +2,85 @@ package archive
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 )
 
-// Client interacts with the Archive API
-type Client struct {
+const (
+	NodeTypeGraphQL    = "graphql"
+	NodeTypePostgreSQL = "postgresql"
+)
+
+// LedgerPageSize is the number of staking ledger records streamed at once
+const LedgerPageSize = 1000
+
+var (
+	// ErrNotSupported is returned when the archive node type does not provide the data
+	ErrNotSupported = errors.New("not supported by archive node type")
+)
+
+// StatusError is returned when the archive API responds with an unexpected status
+type StatusError struct {
+	StatusCode int
+}
+
+func (e StatusError) Error() string {
+	return fmt.Sprintf("archive api returned status %d", e.StatusCode)
+}
+
+// Client interacts with the archive node
+type Client interface {
+	Summary() (*Summary, error)
+	Blocks(blocksReq *BlocksRequest) ([]Block, error)
+	Block(hash string) (*Block, error)
+	StakingLedger(ledgerType string) ([]StakingInfo, error)
+	StreamStakingLedger(ledgerType string, pageSize int, fn func([]StakingInfo) error) error
+}
+
+// NewClient returns a new archive client for the node type
+func NewClient(nodeType string, endpoint string) (Client, error) {
+	switch nodeType {
+	case NodeTypeGraphQL, "":
+		return NewDefaultAPIClient(endpoint), nil
+	case NodeTypePostgreSQL:
+		return NewPostgresClient(endpoint)
+	default:
+		return nil, fmt.Errorf("unsupported archive node type: %s", nodeType)
+	}
+}
+
+// APIClient interacts with the Archive API
+type APIClient struct {
 	endpoint string
 	client   *http.Client
 }
 
-// NewClient returns a new archive service client
-func NewClient(httpClient *http.Client, endpoint string) *Client {
-	return &Client{
+// NewAPIClient returns a new archive service client
+func NewAPIClient(httpClient *http.Client, endpoint string) *APIClient {
+	return &APIClient{
 		endpoint: endpoint,
 		client:   httpClient,
 	}
 }
 
-// NewDefaultClient returns a default archive service client
-func NewDefaultClient(endpoint string) *Client {
-	return NewClient(http.DefaultClient, endpoint)
+// NewDefaultAPIClient returns a default archive service client
+func NewDefaultAPIClient(endpoint string) *APIClient {
+	return NewAPIClient(http.DefaultClient, endpoint)
 }
 
 // Summary returns archive summary
-func (c Client) Summary() (*Summary, error) {
+func (c APIClient) Summary() (*Summary, error) {
 	resp, err := c.client.Get(c.endpoint + "/")
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return nil, err
+	}
 
 	summary := &Summary{}
 	err = json.NewDecoder(resp.Body).Decode(summary)
@@ -40,7 +89,7 @@ func (c Client) Summary() (*Summary, error) {
 }
 
 // Blocks returns blocks matching the request parameters
-func (c Client) Blocks(blocksReq *BlocksRequest) ([]Block, error) {
+func (c APIClient) Blocks(blocksReq *BlocksRequest) ([]Block, error) {
 	req, err := http.NewRequest(http.MethodGet, c.endpoint+"/blocks", nil)
 	if err != nil {
 		return nil, err
@@ -60,6 +109,10 @@ func (c Client) Blocks(blocksReq *BlocksRequest) ([]Block, error) {
 	}
 	defer resp.Body.Close()
 
+	if err := checkStatus(resp); err != nil {
+		return nil, err
+	}
+
 	result := []Block{}
 	err = json.NewDecoder(resp.Body).Decode(&result)
 
@@ -67,13 +120,17 @@ func (c Client) Blocks(blocksReq *BlocksRequest) ([]Block, error) {
 }
 
 // Block returns block for a given hash
-func (c Client) Block(hash string) (*Block, error) {
+func (c APIClient) Block(hash string) (*Block, error) {
 	resp, err := c.client.Get(fmt.Sprintf("%s/blocks/%s", c.endpoint, hash))
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	if err := checkStatus(resp); err != nil {
+		return nil, err
+	}
+
 	block := &Block{}
 	err = json.NewDecoder(resp.Body).Decode(block)
 
@@ -81,7 +138,7 @@ func (c Client) Block(hash string) (*Block, error) {
 }
 
 // StakingLedger returns the staking ledger records
-func (c Client) StakingLedger(ledgerType string) ([]StakingInfo, error) {
+func (c APIClient) StakingLedger(ledgerType string) ([]StakingInfo, error) {
 	path := fmt.Sprintf("%s/staking_ledger?type=%s", c.endpoint, ledgerType)
 
 	resp, err := c.client.Get(path)
@@ -90,8 +147,70 @@ func (c Client) StakingLedger(ledgerType string) ([]StakingInfo, error) {
 	}
 	defer resp.Body.Close()
 
+	if err := checkStatus(resp); err != nil {
+		return nil, err
+	}
+
 	result := []StakingInfo{}
 	err = json.NewDecoder(resp.Body).Decode(&result)
 
 	return result, err
 }
+
+// StreamStakingLedger decodes the staking ledger records incrementally and passes them to fn in pages,
+// so the whole ledger is never held in memory
+func (c APIClient) StreamStakingLedger(ledgerType string, pageSize int, fn func([]StakingInfo) error) error {
+	path := fmt.Sprintf("%s/staking_ledger?type=%s", c.endpoint, ledgerType)
+
+	resp, err := c.client.Get(path)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	if token, err := decoder.Token(); err != nil {
+		return err
+	} else if token != json.Delim('[') {
+		return fmt.Errorf("unexpected staking ledger token: %v", token)
+	}
+
+	page := make([]StakingInfo, 0, pageSize)
+	for decoder.More() {
+		record := StakingInfo{}
+		if err := decoder.Decode(&record); err != nil {
+			return err
+		}
+
+		page = append(page, record)
+		if len(page) < pageSize {
+			continue
+		}
+
+		if err := fn(page); err != nil {
+			return err
+		}
+		page = make([]StakingInfo, 0, pageSize)
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+
+	if len(page) > 0 {
+		return fn(page)
+	}
+	return nil
+}
+
+// checkStatus returns an error if the response status is not successful
+func checkStatus(resp *http.Response) error {
+	if resp.StatusCode >= http.StatusBadRequest {
+		return StatusError{StatusCode: resp.StatusCode}
+	}
+	return nil
+}
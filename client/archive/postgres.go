@@ -0,0 +1,235 @@
+package archive
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+// PostgresClient reads chain data directly from the archive node database
+type PostgresClient struct {
+	db *sql.DB
+}
+
+// NewPostgresClient returns a new archive database client
+func NewPostgresClient(databaseURL string) (*PostgresClient, error) {
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		return nil, err
+	}
+	return &PostgresClient{db: db}, nil
+}
+
+// Close closes the database connection
+func (c PostgresClient) Close() error {
+	return c.db.Close()
+}
+
+// Summary returns archive summary
+func (c PostgresClient) Summary() (*Summary, error) {
+	summary := &Summary{
+		UserCommandsTypes:     map[string]uint{},
+		InternalCommandsTypes: map[string]uint{},
+	}
+
+	err := c.db.QueryRow(sqlSummary).Scan(
+		&summary.BlocksCount,
+		&summary.BlocksMinHeight,
+		&summary.BlocksMaxHeight,
+		&summary.BlocksMinTimestamp,
+		&summary.BlocksMaxTimestamp,
+		&summary.BlocksProducersCount,
+		&summary.PublicKeysCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.countTypes(sqlUserCommandsTypes, summary.UserCommandsTypes); err != nil {
+		return nil, err
+	}
+	for _, n := range summary.UserCommandsTypes {
+		summary.UserCommandsCount += n
+	}
+
+	if err := c.countTypes(sqlInternalCommandsTypes, summary.InternalCommandsTypes); err != nil {
+		return nil, err
+	}
+	for _, n := range summary.InternalCommandsTypes {
+		summary.InternalCommandsCount += n
+	}
+
+	return summary, nil
+}
+
+// Blocks returns blocks matching the request parameters
+func (c PostgresClient) Blocks(blocksReq *BlocksRequest) ([]Block, error) {
+	rows, err := c.db.Query(sqlBlocks, blocksReq.StartHeight, blocksReq.Canonical, blocksReq.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []Block{}
+	for rows.Next() {
+		block := Block{}
+		if _, err := scanBlock(rows, &block); err != nil {
+			return nil, err
+		}
+		result = append(result, block)
+	}
+
+	return result, rows.Err()
+}
+
+// Block returns block for a given hash
+func (c PostgresClient) Block(hash string) (*Block, error) {
+	block := &Block{}
+
+	id, err := scanBlock(c.db.QueryRow(sqlBlockByHash, hash), block)
+	if err != nil {
+		return nil, err
+	}
+
+	if block.UserCommands, err = c.userCommands(id); err != nil {
+		return nil, err
+	}
+	if block.InternalCommands, err = c.internalCommands(id); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+// StakingLedger is not available since the archive database does not store ledgers
+func (c PostgresClient) StakingLedger(ledgerType string) ([]StakingInfo, error) {
+	return nil, ErrNotSupported
+}
+
+// StreamStakingLedger is not available since the archive database does not store ledgers
+func (c PostgresClient) StreamStakingLedger(ledgerType string, pageSize int, fn func([]StakingInfo) error) error {
+	return ErrNotSupported
+}
+
+func (c PostgresClient) userCommands(blockID int) ([]UserCommand, error) {
+	rows, err := c.db.Query(sqlUserCommands, blockID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []UserCommand{}
+	for rows.Next() {
+		cmd := UserCommand{}
+		err := rows.Scan(
+			&cmd.Hash,
+			&cmd.Type,
+			&cmd.FeeToken,
+			&cmd.Token,
+			&cmd.Nonce,
+			&cmd.Amount,
+			&cmd.Fee,
+			&cmd.ValidUntil,
+			&cmd.Memo,
+			&cmd.Status,
+			&cmd.FailureReason,
+			&cmd.FeePayerAccountCreationFeePaid,
+			&cmd.ReceiverAccountCreationFeePaid,
+			&cmd.CreatedToken,
+			&cmd.SequenceNo,
+			&cmd.FeePayer,
+			&cmd.Sender,
+			&cmd.Receiver,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, cmd)
+	}
+
+	return result, rows.Err()
+}
+
+func (c PostgresClient) internalCommands(blockID int) ([]InternalCommand, error) {
+	rows, err := c.db.Query(sqlInternalCommands, blockID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []InternalCommand{}
+	for rows.Next() {
+		var id int
+		cmd := InternalCommand{}
+		err := rows.Scan(
+			&id,
+			&cmd.Hash,
+			&cmd.Type,
+			&cmd.Fee,
+			&cmd.Token,
+			&cmd.Receiver,
+			&cmd.SequenceNo,
+			&cmd.SecondarySequenceNo,
+		)
+		if err != nil {
+			return nil, err
+		}
+		cmd.ID = fmt.Sprintf("%d", id)
+		result = append(result, cmd)
+	}
+
+	return result, rows.Err()
+}
+
+func (c PostgresClient) countTypes(query string, dst map[string]uint) error {
+	rows, err := c.db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			kind  string
+			count uint
+		)
+		if err := rows.Scan(&kind, &count); err != nil {
+			return err
+		}
+		dst[kind] = count
+	}
+
+	return rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanBlock(row rowScanner, block *Block) (int, error) {
+	var id int
+
+	err := row.Scan(
+		&id,
+		&block.Height,
+		&block.StateHash,
+		&block.ParentHash,
+		&block.LedgerHash,
+		&block.SnarkedLedgerHash,
+		&block.Creator,
+		&block.Winner,
+		&block.Timestamp,
+		&block.GlobalSlotSinceGenesis,
+		&block.GlobalSlot,
+		&block.LastVrfOutput,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	block.TimestampFormatted = time.Unix(0, block.Timestamp*int64(time.Millisecond)).UTC().Format(time.RFC3339)
+
+	return id, nil
+}
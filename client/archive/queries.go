@@ -0,0 +1,93 @@
+package archive
+
+const (
+	sqlBlockFields = `
+		SELECT
+			blocks.id,
+			blocks.height,
+			blocks.state_hash,
+			blocks.parent_hash,
+			blocks.ledger_hash,
+			snarked_ledger_hashes.value,
+			creators.value,
+			winners.value,
+			blocks.timestamp::BIGINT,
+			blocks.global_slot_since_genesis,
+			blocks.global_slot,
+			COALESCE(blocks.last_vrf_output, '')
+		FROM blocks
+		INNER JOIN public_keys creators ON creators.id = blocks.creator_id
+		INNER JOIN public_keys winners ON winners.id = blocks.block_winner_id
+		INNER JOIN snarked_ledger_hashes ON snarked_ledger_hashes.id = blocks.snarked_ledger_hash_id`
+
+	sqlBlocks = sqlBlockFields + `
+		WHERE
+			blocks.height >= $1
+			AND ($2::BOOLEAN IS NULL OR (blocks.chain_status = 'canonical') = $2)
+		ORDER BY blocks.height ASC
+		LIMIT $3`
+
+	sqlBlockByHash = sqlBlockFields + `
+		WHERE blocks.state_hash = $1`
+
+	sqlUserCommands = `
+		SELECT
+			user_commands.hash,
+			user_commands.type,
+			user_commands.fee_token,
+			user_commands.token,
+			user_commands.nonce,
+			COALESCE(user_commands.amount, 0),
+			user_commands.fee,
+			user_commands.valid_until,
+			user_commands.memo,
+			blocks_user_commands.status,
+			blocks_user_commands.failure_reason,
+			blocks_user_commands.fee_payer_account_creation_fee_paid,
+			blocks_user_commands.receiver_account_creation_fee_paid,
+			blocks_user_commands.created_token,
+			blocks_user_commands.sequence_no,
+			fee_payers.value,
+			sources.value,
+			receivers.value
+		FROM blocks_user_commands
+		INNER JOIN user_commands ON user_commands.id = blocks_user_commands.user_command_id
+		INNER JOIN public_keys fee_payers ON fee_payers.id = user_commands.fee_payer_id
+		INNER JOIN public_keys sources ON sources.id = user_commands.source_id
+		INNER JOIN public_keys receivers ON receivers.id = user_commands.receiver_id
+		WHERE blocks_user_commands.block_id = $1
+		ORDER BY blocks_user_commands.sequence_no`
+
+	sqlInternalCommands = `
+		SELECT
+			internal_commands.id,
+			internal_commands.hash,
+			internal_commands.type,
+			internal_commands.fee,
+			internal_commands.token,
+			receivers.value,
+			blocks_internal_commands.sequence_no,
+			blocks_internal_commands.secondary_sequence_no
+		FROM blocks_internal_commands
+		INNER JOIN internal_commands ON internal_commands.id = blocks_internal_commands.internal_command_id
+		INNER JOIN public_keys receivers ON receivers.id = internal_commands.receiver_id
+		WHERE blocks_internal_commands.block_id = $1
+		ORDER BY blocks_internal_commands.sequence_no, blocks_internal_commands.secondary_sequence_no`
+
+	sqlSummary = `
+		SELECT
+			COUNT(1),
+			COALESCE(MIN(height), 0),
+			COALESCE(MAX(height), 0),
+			COALESCE(MIN(timestamp::BIGINT), 0),
+			COALESCE(MAX(timestamp::BIGINT), 0),
+			COUNT(DISTINCT creator_id),
+			(SELECT COUNT(1) FROM public_keys)
+		FROM blocks`
+
+	sqlUserCommandsTypes = `
+		SELECT type, COUNT(1) FROM user_commands GROUP BY type`
+
+	sqlInternalCommandsTypes = `
+		SELECT type, COUNT(1) FROM internal_commands GROUP BY type`
+)
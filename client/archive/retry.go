@@ -0,0 +1,123 @@
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const defaultInitialBackoff = time.Second
+
+// RetryClient retries transient archive request failures with exponential backoff
+type RetryClient struct {
+	client         Client
+	maxRetries     int
+	initialBackoff time.Duration
+}
+
+// NewRetryClient returns a new client retrying the requests of the given client
+func NewRetryClient(client Client, maxRetries int, initialBackoff time.Duration) *RetryClient {
+	if initialBackoff <= 0 {
+		initialBackoff = defaultInitialBackoff
+	}
+
+	return &RetryClient{
+		client:         client,
+		maxRetries:     maxRetries,
+		initialBackoff: initialBackoff,
+	}
+}
+
+// Summary returns archive summary
+func (c RetryClient) Summary() (result *Summary, err error) {
+	err = c.retry("summary", func() error {
+		result, err = c.client.Summary()
+		return err
+	})
+	return
+}
+
+// Blocks returns blocks matching the request parameters
+func (c RetryClient) Blocks(blocksReq *BlocksRequest) (result []Block, err error) {
+	err = c.retry("blocks", func() error {
+		result, err = c.client.Blocks(blocksReq)
+		return err
+	})
+	return
+}
+
+// Block returns block for a given hash
+func (c RetryClient) Block(hash string) (result *Block, err error) {
+	err = c.retry("block", func() error {
+		result, err = c.client.Block(hash)
+		return err
+	})
+	return
+}
+
+// StakingLedger returns the staking ledger records
+func (c RetryClient) StakingLedger(ledgerType string) (result []StakingInfo, err error) {
+	err = c.retry("staking_ledger", func() error {
+		result, err = c.client.StakingLedger(ledgerType)
+		return err
+	})
+	return
+}
+
+// StreamStakingLedger streams the staking ledger records.
+// The request is only retried until the first page is delivered.
+func (c RetryClient) StreamStakingLedger(ledgerType string, pageSize int, fn func([]StakingInfo) error) error {
+	delivered := false
+
+	return c.retry("staking_ledger", func() error {
+		err := c.client.StreamStakingLedger(ledgerType, pageSize, func(page []StakingInfo) error {
+			delivered = true
+			return fn(page)
+		})
+		if err != nil && delivered {
+			// Drop the error chain so the partially processed stream is not retried
+			return fmt.Errorf("staking ledger stream interrupted: %v", err)
+		}
+		return err
+	})
+}
+
+func (c RetryClient) retry(request string, fn func() error) error {
+	backoff := c.initialBackoff
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt > c.maxRetries || !isTransient(err) {
+			return err
+		}
+
+		// Add up to 50% of random jitter so retries of concurrent requests spread out
+		delay := backoff + time.Duration(rand.Int63n(int64(backoff)/2+1))
+
+		log.
+			WithError(err).
+			WithField("request", request).
+			WithField("attempt", attempt).
+			WithField("delay", delay).
+			Debug("retrying archive request")
+
+		time.Sleep(delay)
+		backoff *= 2
+	}
+}
+
+// isTransient returns true if the request might succeed when retried
+func isTransient(err error) bool {
+	var statusErr StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -37,8 +37,10 @@ type Block struct {
 	TimestampFormatted     string            `json:"timestamp_formatted"`
 	GlobalSlotSinceGenesis uint              `json:"global_slot_since_genesis"`
 	GlobalSlot             uint              `json:"global_slot"`
+	LastVrfOutput          string            `json:"last_vrf_output"`
 	InternalCommands       []InternalCommand `json:"internal_commands"`
 	UserCommands           []UserCommand     `json:"user_commands"`
+	ZkappCommands          []ZkappCommand    `json:"zkapp_commands"`
 }
 
 type InternalCommand struct {
@@ -71,12 +73,34 @@ type UserCommand struct {
 	FeePayer                       string  `json:"fee_payer"`
 	Sender                         string  `json:"sender"`
 	Receiver                       string  `json:"receiver"`
+	Timestamp                      *int64  `json:"timestamp"`
+}
+
+type ZkappCommand struct {
+	Hash           string               `json:"hash"`
+	FeePayer       string               `json:"fee_payer"`
+	Fee            int64                `json:"fee"`
+	Nonce          int                  `json:"nonce"`
+	Memo           string               `json:"memo"`
+	Status         string               `json:"status"`
+	FailureReason  *string              `json:"failure_reason"`
+	SequenceNo     int                  `json:"sequence_no"`
+	AccountUpdates []ZkappAccountUpdate `json:"account_updates"`
+}
+
+type ZkappAccountUpdate struct {
+	PublicKey      string `json:"public_key"`
+	TokenID        string `json:"token_id"`
+	BalanceChange  int64  `json:"balance_change"`
+	IncrementNonce bool   `json:"increment_nonce"`
+	CallDepth      int    `json:"call_depth"`
 }
 
 type StakingInfo struct {
 	Pk       string `json:"pk"`
 	Balance  string `json:"balance"`
 	Delegate string `json:"delegate"`
+	Nonce    string `json:"nonce"`
 	Timing   *struct {
 		InitialMinimumBalance string `json:"initial_minimum_balance"`
 		CliffTime             string `json:"cliff_time"`
@@ -7,8 +7,10 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"strconv"
+	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -21,29 +23,105 @@ var (
 	ErrBlockInvalid  = errors.New("block is invalid")
 )
 
+// ClientOptions contains the HTTP transport settings of the client
+type ClientOptions struct {
+	Timeout         time.Duration
+	MaxIdleConns    int
+	IdleConnTimeout time.Duration
+	DialTimeout     time.Duration
+}
+
+// DefaultClientOptions are used by the default client
+var DefaultClientOptions = ClientOptions{
+	Timeout:         time.Minute * 5,
+	MaxIdleConns:    10,
+	IdleConnTimeout: time.Second * 90,
+	DialTimeout:     time.Second * 30,
+}
+
 // Client is a GraphQL API client
 type Client struct {
-	endpoint string
-	client   *http.Client
-	debug    bool
+	endpoints []string
+	client    *http.Client
+	failures  *endpointFailures
+	debug     bool
+}
+
+// endpointFailures counts the failed requests of each endpoint and keeps
+// the index of the last endpoint that responded
+type endpointFailures struct {
+	sync.Mutex
+	counts  map[string]int
+	healthy int
+}
+
+func (f *endpointFailures) lastHealthy() int {
+	f.Lock()
+	defer f.Unlock()
+
+	return f.healthy
+}
+
+func (f *endpointFailures) setHealthy(idx int) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.healthy = idx
+}
+
+func (f *endpointFailures) inc(endpoint string) int {
+	f.Lock()
+	defer f.Unlock()
+
+	f.counts[endpoint]++
+	return f.counts[endpoint]
 }
 
-// NewClient returns a new client for a given endpoint
-func NewClient(client *http.Client, endpoint string) *Client {
+// NewClient returns a new client with a dedicated connection pool. Requests fail over
+// to the next endpoint when a request to an endpoint fails or times out.
+func NewClient(endpoints []string, opts ClientOptions) *Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = opts.MaxIdleConns
+	transport.MaxIdleConnsPerHost = opts.MaxIdleConns
+	transport.IdleConnTimeout = opts.IdleConnTimeout
+	transport.DialContext = (&net.Dialer{
+		Timeout:   opts.DialTimeout,
+		KeepAlive: time.Second * 30,
+	}).DialContext
+
 	return &Client{
-		client:   client,
-		endpoint: endpoint,
+		endpoints: endpoints,
+		client: &http.Client{
+			Timeout:   opts.Timeout,
+			Transport: transport,
+		},
+		failures: &endpointFailures{counts: map[string]int{}},
 	}
 }
 
+// NewMultiClient returns a new failover client with the request timeout
+func NewMultiClient(endpoints []string, timeout time.Duration) *Client {
+	opts := DefaultClientOptions
+	opts.Timeout = timeout
+
+	return NewClient(endpoints, opts)
+}
+
 // NewDefaultClient returns a default client for a given endpoint
 func NewDefaultClient(endpoint string) *Client {
-	return &Client{
-		client: &http.Client{
-			Timeout: time.Minute * 5,
-		},
-		endpoint: endpoint,
+	return NewClient([]string{endpoint}, DefaultClientOptions)
+}
+
+// Failures returns the number of failed requests of each endpoint
+func (c Client) Failures() map[string]int {
+	c.failures.Lock()
+	defer c.failures.Unlock()
+
+	result := make(map[string]int, len(c.failures.counts))
+	for endpoint, n := range c.failures.counts {
+		result[endpoint] = n
 	}
+	return result
 }
 
 func (c *Client) SetDebug(enabled bool) {
@@ -52,30 +130,53 @@ func (c *Client) SetDebug(enabled bool) {
 
 // Execute make a GraphQL query and returns the response
 func (c Client) Execute(ctx context.Context, q string) (*GraphResponse, error) {
-	r := map[string]string{"query": q}
+	return c.ExecuteWithVariables(ctx, q, nil)
+}
+
+// ExecuteWithVariables makes a GraphQL query with the given variables and returns the response
+func (c Client) ExecuteWithVariables(ctx context.Context, q string, vars map[string]interface{}) (*GraphResponse, error) {
+	return c.execute(ctx, q, vars, func(error) bool { return true })
+}
+
+// execute sends the request starting with the last endpoint that responded. The next endpoint
+// is only tried when the request to the previous one fails with an error accepted by failover.
+func (c Client) execute(ctx context.Context, q string, vars map[string]interface{}, failover func(error) bool) (*GraphResponse, error) {
+	r := map[string]interface{}{"query": q}
+	if len(vars) > 0 {
+		r["variables"] = vars
+	}
 	data, err := json.MarshalIndent(r, "", "  ")
 	if err != nil {
 		return nil, err
 	}
-	reqBody := bytes.NewReader(data)
 
 	if c.debug {
 		fmt.Printf("%s\n", q)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, reqBody)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Add("Content-Type", "application/json")
+	var respBody []byte
+	start := c.failures.lastHealthy()
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	for i := range c.endpoints {
+		idx := (start + i) % len(c.endpoints)
+		endpoint := c.endpoints[idx]
 
-	respBody, err := ioutil.ReadAll(resp.Body)
+		respBody, err = c.post(ctx, endpoint, data)
+		if err == nil {
+			c.failures.setHealthy(idx)
+			break
+		}
+		if ctx.Err() != nil || !failover(err) {
+			return nil, err
+		}
+		if len(c.endpoints) > 1 {
+			log.
+				WithError(err).
+				WithField("endpoint", endpoint).
+				WithField("failures", c.failures.inc(endpoint)).
+				Warn("skipping failed mina graph endpoint")
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -95,12 +196,40 @@ func (c Client) Execute(ctx context.Context, q string) (*GraphResponse, error) {
 	}
 
 	if len(graphResp.Errors) > 0 {
-		return nil, errors.New(graphResp.Errors[0].Message)
+		return nil, ResponseError{Errors: graphResp.Errors}
 	}
 
 	return &graphResp, nil
 }
 
+// post sends the request body to the endpoint and returns the response body
+func (c Client) post(ctx context.Context, endpoint string, data []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return nil, fmt.Errorf("endpoint is unavailable: %s", resp.Status)
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+// isDialError returns true if the request failed before the connection was established
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
 // Query executes the query and parses the result
 func (c Client) Query(input string, out interface{}) error {
 	resp, err := c.Execute(context.Background(), input)
@@ -119,6 +248,15 @@ func (c Client) QueryWithContext(ctx context.Context, input string, out interfac
 	return resp.Decode(out)
 }
 
+// QueryWithVariables executes the query with the given variables and parses the result
+func (c Client) QueryWithVariables(ctx context.Context, input string, vars map[string]interface{}, out interface{}) error {
+	resp, err := c.ExecuteWithVariables(ctx, input, vars)
+	if err != nil {
+		return err
+	}
+	return resp.Decode(out)
+}
+
 // GetDaemonStatus returns current node daemon status
 func (c Client) GetDaemonStatus(ctx context.Context) (*DaemonStatus, error) {
 	var result struct {
@@ -130,6 +268,26 @@ func (c Client) GetDaemonStatus(ctx context.Context) (*DaemonStatus, error) {
 	return &result.DaemonStatus, nil
 }
 
+// GetNetworkIdentity returns the chain id and genesis timestamp of the network
+func (c Client) GetNetworkIdentity(ctx context.Context) (*NetworkIdentity, error) {
+	var result struct {
+		DaemonStatus struct {
+			ChainID string `json:"chainId"`
+		} `json:"daemonStatus"`
+		GenesisConstants struct {
+			GenesisTimestamp string `json:"genesisTimestamp"`
+		} `json:"genesisConstants"`
+	}
+	if err := c.QueryWithContext(ctx, queryNetworkIdentity, &result); err != nil {
+		return nil, err
+	}
+
+	return &NetworkIdentity{
+		ChainID:          result.DaemonStatus.ChainID,
+		GenesisTimestamp: result.GenesisConstants.GenesisTimestamp,
+	}, nil
+}
+
 // GetCurrentHeight returns the current blockchain height
 func (c Client) GetCurrentHeight() (int64, error) {
 	block, err := c.GetLastBlock()
@@ -164,12 +322,17 @@ func (c Client) GetBestChain() ([]Block, error) {
 
 // GetBlock returns a single block for the given state hash
 func (c Client) GetBlock(hash string) (*Block, error) {
+	return c.GetBlockWithContext(context.Background(), hash)
+}
+
+// GetBlockWithContext returns a single block for the given state hash
+func (c Client) GetBlockWithContext(ctx context.Context, hash string) (*Block, error) {
 	q := fmt.Sprintf(queryBlock, hash, queryBlockFields)
 	result := struct {
 		Block Block `json:"block"`
 	}{}
 
-	if err := c.Query(q, &result); err != nil {
+	if err := c.QueryWithContext(ctx, q, &result); err != nil {
 		return nil, err
 	}
 
@@ -246,6 +409,18 @@ func (c Client) GetAccount(publicKey string) (*Account, error) {
 	return &result.Account, nil
 }
 
+// GetAccountTokens returns all token accounts for a given public key
+func (c Client) GetAccountTokens(ctx context.Context, publicKey string) ([]Account, error) {
+	var result struct {
+		Accounts []Account `json:"accounts"`
+	}
+	vars := map[string]interface{}{"publicKey": publicKey}
+	if err := c.QueryWithVariables(ctx, queryAccountTokens, vars, &result); err != nil {
+		return nil, err
+	}
+	return result.Accounts, nil
+}
+
 func (c Client) ConsensusTip() (*Block, error) {
 	var result struct {
 		Blocks []Block `json:"bestChain"`
@@ -260,10 +435,15 @@ func (c Client) ConsensusTip() (*Block, error) {
 
 // GetPendingTransactions returns pending transactions
 func (c Client) GetPendingTransactions() ([]PendingTransaction, error) {
+	return c.GetPooledTransactions(context.Background())
+}
+
+// GetPooledTransactions returns the user transactions in the node transaction pool
+func (c Client) GetPooledTransactions(ctx context.Context) ([]PendingTransaction, error) {
 	var result struct {
 		Transactions []PendingTransaction `json:"pooledUserCommands"`
 	}
-	if err := c.Query(queryPendingTx, &result); err != nil {
+	if err := c.QueryWithContext(ctx, queryPendingTx, &result); err != nil {
 		return nil, err
 	}
 
@@ -273,3 +453,41 @@ func (c Client) GetPendingTransactions() ([]PendingTransaction, error) {
 
 	return result.Transactions, nil
 }
+
+// SendSignedTransaction broadcasts a payment signed by the sender
+func (c Client) SendSignedTransaction(ctx context.Context, input SendPaymentInput, signature SignatureInput) (*TransactionResult, error) {
+	var result struct {
+		SendPayment struct {
+			Payment TransactionResult `json:"payment"`
+		} `json:"sendPayment"`
+	}
+
+	vars := map[string]interface{}{
+		"input":     input,
+		"signature": signature,
+	}
+	// The payment might be broadcast by an endpoint that timed out,
+	// so other endpoints are only tried when the connection fails
+	resp, err := c.execute(ctx, mutationSendPayment, vars, isDialError)
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result.SendPayment.Payment, nil
+}
+
+// GetPendingTransactionCount returns the number of pending user transactions in the pool
+func (c Client) GetPendingTransactionCount(ctx context.Context) (int, error) {
+	var result struct {
+		Transactions []struct {
+			Hash string `json:"hash"`
+		} `json:"pooledUserCommands"`
+	}
+	if err := c.QueryWithContext(ctx, queryPendingTxCount, &result); err != nil {
+		return 0, err
+	}
+	return len(result.Transactions), nil
+}
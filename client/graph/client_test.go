@@ -0,0 +1,68 @@
+package graph
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiClientFailover(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer down.Close()
+
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"daemonStatus":{"syncStatus":"SYNCED"}}}`))
+	}))
+	defer up.Close()
+
+	client := NewMultiClient([]string{down.URL, up.URL}, time.Second)
+
+	status, err := client.GetDaemonStatus(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, SyncStatusSynced, status.SyncStatus)
+
+	// The endpoint that responded is used first by the next request
+	_, err = client.GetDaemonStatus(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{down.URL: 1}, client.Failures())
+
+	client = NewMultiClient([]string{down.URL}, time.Second)
+	_, err = client.GetDaemonStatus(context.Background())
+	assert.Error(t, err)
+}
+
+func TestSendPaymentFailover(t *testing.T) {
+	calls := 0
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"data":{"sendPayment":{"payment":{"hash":"CkpHash"}}}}`))
+	}))
+	defer up.Close()
+
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGatewayTimeout)
+	}))
+	defer down.Close()
+
+	// Payment is not sent again when the endpoint received the request
+	client := NewMultiClient([]string{down.URL, up.URL}, time.Second)
+	_, err := client.SendSignedTransaction(context.Background(), SendPaymentInput{}, SignatureInput{})
+	assert.Error(t, err)
+	assert.Equal(t, 0, calls)
+
+	// Unreachable endpoint is skipped
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	client = NewMultiClient([]string{closed.URL, up.URL}, time.Second)
+	result, err := client.SendSignedTransaction(context.Background(), SendPaymentInput{}, SignatureInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, "CkpHash", result.Hash)
+	assert.Equal(t, 1, calls)
+}
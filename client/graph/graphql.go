@@ -9,6 +9,16 @@ type GraphError struct {
 	Message string `json:"message"`
 }
 
+// ResponseError is returned when the GraphQL response contains errors
+type ResponseError struct {
+	Errors []GraphError
+}
+
+// Error returns the first error message
+func (e ResponseError) Error() string {
+	return e.Errors[0].Message
+}
+
 // GraphResponse contains the GraphQL call response
 type GraphResponse struct {
 	Errors []GraphError    `json:"errors"`
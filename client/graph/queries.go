@@ -80,6 +80,13 @@ var (
 				total
 				unknown
 			}
+			timing {
+				initialMinimumBalance
+				cliffTime
+				cliffAmount
+				vestingPeriod
+				vestingIncrement
+			}
 		}
 		protocolState {
 			blockchainState {
@@ -141,6 +148,33 @@ var (
 					total
 					blockHeight
 				}
+				timing {
+					initialMinimumBalance
+					cliffTime
+					cliffAmount
+					vestingPeriod
+					vestingIncrement
+				}
+			}
+		}`
+
+	queryAccountTokens = `
+		query($publicKey: PublicKey!) {
+			accounts(publicKey: $publicKey) {
+				publicKey
+				tokenId
+				tokenSymbol
+				balance {
+					total
+					blockHeight
+				}
+			}
+		}`
+
+	queryPendingTxCount = `
+		query {
+			pooledUserCommands {
+				hash
 			}
 		}`
 
@@ -159,6 +193,28 @@ var (
 				to
 			}
 		}`
+
+	queryNetworkIdentity = `
+		query {
+			daemonStatus {
+				chainId
+			}
+			genesisConstants {
+				genesisTimestamp
+			}
+		}`
+
+	// Broadcast a signed payment
+	mutationSendPayment = `
+		mutation($input: SendPaymentInput!, $signature: SignatureInput) {
+			sendPayment(input: $input, signature: $signature) {
+				payment {
+					id
+					hash
+					nonce
+				}
+			}
+		}`
 )
 
 func buildBestChainQuery() string {
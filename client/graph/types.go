@@ -39,6 +39,25 @@ type Account struct {
 	PrivateKeyPath string `json:"privateKeyPath"`
 	// True if locked, false if unlocked, null if the account isn't tracked by the queried daemon
 	Locked *bool `json:"locked"`
+	// The token associated with this account
+	TokenID string `json:"tokenId"`
+	// The symbol for the token owned by this account, if there is one
+	TokenSymbol *string `json:"tokenSymbol"`
+	// The vesting schedule of the account, if there is one
+	Timing *AccountTiming `json:"timing"`
+}
+
+type AccountTiming struct {
+	// The minimum balance for a time-locked account
+	InitialMinimumBalance *string `json:"initialMinimumBalance"`
+	// The cliff time for a time-locked account
+	CliffTime *string `json:"cliffTime"`
+	// The cliff amount for a time-locked account
+	CliffAmount *string `json:"cliffAmount"`
+	// The vesting period for a time-locked account
+	VestingPeriod *string `json:"vestingPeriod"`
+	// The vesting increment for a time-locked account
+	VestingIncrement *string `json:"vestingIncrement"`
 }
 
 type AddAccountInput struct {
@@ -707,3 +726,19 @@ func (e *Sign) UnmarshalGQL(v interface{}) error {
 func (e Sign) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
+
+type NetworkIdentity struct {
+	// The chain id of the network
+	ChainID string `json:"chain_id"`
+	// The genesis timestamp of the network
+	GenesisTimestamp string `json:"genesis_timestamp"`
+}
+
+type TransactionResult struct {
+	// The transaction id
+	ID string `json:"id"`
+	// The transaction hash
+	Hash string `json:"hash"`
+	// Nonce of the transaction
+	Nonce int `json:"nonce"`
+}
@@ -15,54 +15,102 @@ import (
 const (
 	modeDevelopment = "development"
 	modeProduction  = "production"
+
+	archiveNodeGraphQL    = "graphql"
+	archiveNodePostgreSQL = "postgresql"
 )
 
 var (
-	errEndpointRequired        = errors.New("Coda API endpoint is required")
-	errEndpointInvalid         = errors.New("Coda API endpoint is invalid")
-	errDatabaseRequired        = errors.New("Database credentials are required")
-	errSyncIntervalRequired    = errors.New("Sync interval is required")
-	errSyncIntervalInvalid     = errors.New("Sync interval is invalid")
-	errCleanupIntervalRequired = errors.New("Cleanup interval is required")
-	errCleanupIntervalInvalid  = errors.New("Cleanup interval is invalid")
+	errEndpointRequired          = errors.New("Coda API endpoint is required")
+	errEndpointInvalid           = errors.New("Coda API endpoint is invalid")
+	errDatabaseRequired          = errors.New("Database credentials are required")
+	errSyncIntervalRequired      = errors.New("Sync interval is required")
+	errSyncIntervalInvalid       = errors.New("Sync interval is invalid")
+	errCleanupIntervalRequired   = errors.New("Cleanup interval is required")
+	errCleanupIntervalInvalid    = errors.New("Cleanup interval is invalid")
+	errTLSFilesRequired          = errors.New("Both TLS cert and key files are required")
+	errArchiveNodeTypeInvalid    = errors.New("Archive node type is invalid")
+	errSlowQueryThresholdInvalid = errors.New("Slow query threshold is invalid")
+	errMaxRetriesInvalid         = errors.New("Max retries must not be negative")
+	errInitialBackoffInvalid     = errors.New("Initial backoff is invalid")
+	errIdleConnTimeoutInvalid    = errors.New("GraphQL idle connection timeout is invalid")
+	errDialTimeoutInvalid        = errors.New("GraphQL dial timeout is invalid")
 )
 
 // Config holds the configration data
 type Config struct {
-	AppEnv           string `json:"app_env" envconfig:"APP_ENV" default:"development"`
-	MinaEndpoint     string `json:"mina_endpoint" envconfig:"MINA_ENDPOINT"`
-	ArchiveEndpoint  string `json:"archive_endpoint" envconfig:"ARCHIVE_ENDPOINT"`
-	GenesisFile      string `json:"genesis_file" envconfig:"GENESIS_FILE"`
-	IdentityFile     string `json:"identity_file" envconfig:"IDENTITY_FILE"`
-	ServerAddr       string `json:"server_addr" envconfig:"SERVER_ADDR" default:"0.0.0.0"`
-	ServerPort       int    `json:"server_port" envconfig:"SERVER_PORT" default:"8080"`
-	SyncInterval     string `json:"sync_interval" envconfig:"SYNC_INTERVAL" default:"60s"`
-	CleanupInterval  string `json:"cleanup_interval" envconfig:"CLEANUP_INTERVAL" default:"10m"`
-	CleanupThreshold int    `json:"cleanup_threshold" envconfig:"CLEANUP_THRESHOLD" default:"1000"`
-	DatabaseURL      string `json:"database_url" envconfig:"DATABASE_URL"`
-	DumpDir          string `json:"dump_dir" envconfig:"DUMP_DIR"`
-	LogLevel         string `json:"log_level" envconfig:"LOG_LEVEL" default:"info"`
-	LogFormat        string `json:"log_format" envconfig:"LOG_FORMAT" default:"text"`
-	RollbarToken     string `json:"rollbar_token" envconfig:"ROLLBAR_TOKEN"`
-	RollbarNamespace string `json:"rollbar_namespace" envconfig:"ROLLBAR_NAMESPACE"`
-
-	HistoricalLimit uint `json:"historical_limit" envconfig:"HISTORICAL_LIMIT" default:"290"`
-
-	syncDuration    time.Duration
-	cleanupDuration time.Duration
+	AppEnv             string   `json:"app_env" envconfig:"APP_ENV" default:"development"`
+	MinaEndpoint       string   `json:"mina_endpoint" envconfig:"MINA_ENDPOINT"`
+	MinaEndpoints      []string `json:"mina_endpoints" envconfig:"MINA_ENDPOINTS"`
+	ArchiveEndpoint    string   `json:"archive_endpoint" envconfig:"ARCHIVE_ENDPOINT"`
+	ArchiveNodeType    string   `json:"archive_node_type" envconfig:"ARCHIVE_NODE_TYPE" default:"graphql"`
+	MaxRetries         int      `json:"max_retries" envconfig:"MAX_RETRIES" default:"3"`
+	InitialBackoff     string   `json:"initial_backoff" envconfig:"INITIAL_BACKOFF" default:"1s"`
+	GenesisFile        string   `json:"genesis_file" envconfig:"GENESIS_FILE"`
+	IdentityFile       string   `json:"identity_file" envconfig:"IDENTITY_FILE"`
+	IdentityURL        string   `json:"identity_url" envconfig:"IDENTITY_URL"`
+	ServerAddr         string   `json:"server_addr" envconfig:"SERVER_ADDR" default:"0.0.0.0"`
+	ServerPort         int      `json:"server_port" envconfig:"SERVER_PORT" default:"8080"`
+	TLSCertFile        string   `json:"tls_cert_file" envconfig:"TLS_CERT_FILE"`
+	TLSKeyFile         string   `json:"tls_key_file" envconfig:"TLS_KEY_FILE"`
+	CORSAllowedOrigins []string `json:"cors_allowed_origins" envconfig:"CORS_ALLOWED_ORIGINS"`
+	MaxBodyBytes       int64    `json:"max_body_bytes" envconfig:"MAX_BODY_BYTES" default:"1048576"`
+	SyncInterval       string   `json:"sync_interval" envconfig:"SYNC_INTERVAL" default:"60s"`
+	CleanupInterval    string   `json:"cleanup_interval" envconfig:"CLEANUP_INTERVAL" default:"10m"`
+	CleanupThreshold   int      `json:"cleanup_threshold" envconfig:"CLEANUP_THRESHOLD" default:"1000"`
+	SlowQueryThreshold string   `json:"slow_query_threshold" envconfig:"SLOW_QUERY_THRESHOLD"`
+	DatabaseURL        string   `json:"database_url" envconfig:"DATABASE_URL"`
+	MaxOpenConns       int      `json:"max_open_conns" envconfig:"DATABASE_MAX_OPEN_CONNS"`
+	MaxIdleConns       int      `json:"max_idle_conns" envconfig:"DATABASE_MAX_IDLE_CONNS" default:"10"`
+	HealthCheckNode    bool     `json:"health_check_node" envconfig:"HEALTH_CHECK_NODE"`
+	DumpDir            string   `json:"dump_dir" envconfig:"DUMP_DIR"`
+	LogLevel           string   `json:"log_level" envconfig:"LOG_LEVEL" default:"info"`
+	LogFormat          string   `json:"log_format" envconfig:"LOG_FORMAT" default:"text"`
+	RollbarToken       string   `json:"rollbar_token" envconfig:"ROLLBAR_TOKEN"`
+	RollbarNamespace   string   `json:"rollbar_namespace" envconfig:"ROLLBAR_NAMESPACE"`
+
+	HistoricalLimit    uint   `json:"historical_limit" envconfig:"HISTORICAL_LIMIT" default:"290"`
+	SyncFromHeight     uint64 `json:"sync_from_height" envconfig:"SYNC_FROM_HEIGHT"`
+	DeltaSyncBatchSize uint   `json:"delta_sync_batch_size" envconfig:"DELTA_SYNC_BATCH_SIZE" default:"100"`
+	PrepareWorkers     int    `json:"prepare_workers" envconfig:"PREPARE_WORKERS" default:"4"`
+	MetricsAddr        string `json:"metrics_addr" envconfig:"METRICS_ADDR"`
+
+	MaxResponseBodyBytes  int64  `json:"max_response_body_bytes" envconfig:"MAX_RESPONSE_BODY_BYTES" default:"52428800"`
+	CaseInsensitiveLookup bool   `json:"case_insensitive_lookup" envconfig:"CASE_INSENSITIVE_LOOKUP" default:"true"`
+	AdminToken            string `json:"admin_token" envconfig:"ADMIN_TOKEN"`
+	ReadReplicaDSN        string `json:"read_replica_dsn" envconfig:"READ_REPLICA_DSN"`
+
+	GraphQL GraphQLConfig `json:"graphql" envconfig:"GRAPHQL"`
+
+	syncDuration      time.Duration
+	cleanupDuration   time.Duration
+	slowQueryDuration time.Duration
+	backoffDuration   time.Duration
+}
+
+// GraphQLConfig holds the connection settings of the Mina GraphQL client
+type GraphQLConfig struct {
+	MaxIdleConns    int    `json:"max_idle_conns" envconfig:"MAX_IDLE_CONNS" default:"10"`
+	IdleConnTimeout string `json:"idle_conn_timeout" envconfig:"IDLE_CONN_TIMEOUT" default:"90s"`
+	DialTimeout     string `json:"dial_timeout" envconfig:"DIAL_TIMEOUT" default:"30s"`
+
+	idleConnDuration time.Duration
+	dialDuration     time.Duration
 }
 
 // Validate returns an error if config is invalid
 func (c *Config) Validate() error {
-	if c.MinaEndpoint == "" {
+	if c.MinaEndpoint == "" && len(c.MinaEndpoints) == 0 {
 		return errEndpointRequired
 	}
-	codaURL, err := url.Parse(c.MinaEndpoint)
-	if err != nil {
-		return errEndpointInvalid
-	}
-	if !strings.Contains(codaURL.Path, "graphql") {
-		return errEndpointInvalid
+	for _, endpoint := range c.GraphEndpoints() {
+		codaURL, err := url.Parse(endpoint)
+		if err != nil {
+			return errEndpointInvalid
+		}
+		if !strings.Contains(codaURL.Path, "graphql") {
+			return errEndpointInvalid
+		}
 	}
 
 	if c.DatabaseURL == "" {
@@ -87,9 +135,47 @@ func (c *Config) Validate() error {
 	}
 	c.cleanupDuration = d
 
+	if c.SlowQueryThreshold != "" {
+		d, err = time.ParseDuration(c.SlowQueryThreshold)
+		if err != nil {
+			return errSlowQueryThresholdInvalid
+		}
+		c.slowQueryDuration = d
+	}
+
+	if c.MaxRetries < 0 {
+		return errMaxRetriesInvalid
+	}
+	if c.InitialBackoff != "" {
+		d, err = time.ParseDuration(c.InitialBackoff)
+		if err != nil || d < 0 {
+			return errInitialBackoffInvalid
+		}
+		c.backoffDuration = d
+	}
+
+	if err := c.GraphQL.validate(); err != nil {
+		return err
+	}
+
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		return errTLSFilesRequired
+	}
+
+	switch c.ArchiveNodeType {
+	case "", archiveNodeGraphQL, archiveNodePostgreSQL:
+	default:
+		return errArchiveNodeTypeInvalid
+	}
+
 	return nil
 }
 
+// TLSEnabled returns true if server should terminate TLS connections
+func (c *Config) TLSEnabled() bool {
+	return c.TLSCertFile != "" && c.TLSKeyFile != ""
+}
+
 // IsDevelopment returns true if app is in dev mode
 func (c *Config) IsDevelopment() bool {
 	return c.AppEnv == modeDevelopment
@@ -105,6 +191,14 @@ func (c *Config) ListenAddr() string {
 	return fmt.Sprintf("%s:%d", c.ServerAddr, c.ServerPort)
 }
 
+// GraphEndpoints returns the Mina GraphQL endpoints in the failover order
+func (c *Config) GraphEndpoints() []string {
+	if len(c.MinaEndpoints) > 0 {
+		return c.MinaEndpoints
+	}
+	return []string{c.MinaEndpoint}
+}
+
 // SyncDuration returns the parsed duration for the sync pipeline
 func (c *Config) SyncDuration() time.Duration {
 	return c.syncDuration
@@ -115,6 +209,46 @@ func (c *Config) CleanupDuration() time.Duration {
 	return c.cleanupDuration
 }
 
+// SlowQueryDuration returns the parsed slow query threshold
+func (c *Config) SlowQueryDuration() time.Duration {
+	return c.slowQueryDuration
+}
+
+// InitialBackoffDuration returns the parsed delay before the first archive request retry
+func (c *Config) InitialBackoffDuration() time.Duration {
+	return c.backoffDuration
+}
+
+func (c *GraphQLConfig) validate() error {
+	if c.IdleConnTimeout != "" {
+		d, err := time.ParseDuration(c.IdleConnTimeout)
+		if err != nil {
+			return errIdleConnTimeoutInvalid
+		}
+		c.idleConnDuration = d
+	}
+
+	if c.DialTimeout != "" {
+		d, err := time.ParseDuration(c.DialTimeout)
+		if err != nil {
+			return errDialTimeoutInvalid
+		}
+		c.dialDuration = d
+	}
+
+	return nil
+}
+
+// IdleConnTimeoutDuration returns the parsed idle connection timeout
+func (c GraphQLConfig) IdleConnTimeoutDuration() time.Duration {
+	return c.idleConnDuration
+}
+
+// DialTimeoutDuration returns the parsed dial timeout
+func (c GraphQLConfig) DialTimeoutDuration() time.Duration {
+	return c.dialDuration
+}
+
 // New returns a new config
 func New() *Config {
 	return &Config{}
@@ -129,7 +263,11 @@ func FromFile(path string, config *Config) error {
 	return json.Unmarshal(data, config)
 }
 
-// FromEnv reads the config from environment variables
+// FromEnv reads the config from environment variables.
+// The MINA_ prefixed variables take precedence over the unprefixed ones.
 func FromEnv(config *Config) error {
-	return envconfig.Process("", config)
+	if err := envconfig.Process("", config); err != nil {
+		return err
+	}
+	return applyEnv(config, prefixedEnvKey)
 }
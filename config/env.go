@@ -0,0 +1,155 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// EnvPrefix is the prefix of the environment variables that take precedence
+// over both the unprefixed variables and the config file, e.g. MINA_DATABASE_URL
+const EnvPrefix = "MINA_"
+
+const redacted = "REDACTED"
+
+var reDSNPassword = regexp.MustCompile(`password=\S+`)
+
+// LoadFromEnv returns a new config read from the environment variables
+func LoadFromEnv() (*Config, error) {
+	config := New()
+	if err := FromEnv(config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// OverrideFromEnv sets the fields that have a matching environment variable.
+// Unlike FromEnv, the fields without a variable keep their current value.
+func OverrideFromEnv(config *Config) error {
+	if err := applyEnv(config, func(key string) string { return key }); err != nil {
+		return err
+	}
+	return applyEnv(config, prefixedEnvKey)
+}
+
+// prefixedEnvKey returns the MINA_ prefixed variable name,
+// names that already have the prefix are used as is
+func prefixedEnvKey(key string) string {
+	if strings.HasPrefix(key, EnvPrefix) {
+		return key
+	}
+	return EnvPrefix + key
+}
+
+func applyEnv(config *Config, keyFn func(string) string) error {
+	for _, f := range envFields(reflect.ValueOf(config).Elem(), "") {
+		key := keyFn(f.key)
+		value, ok := os.LookupEnv(key)
+		if !ok {
+			continue
+		}
+
+		if err := setField(f.value, value); err != nil {
+			return fmt.Errorf("invalid value of %s: %v", key, err)
+		}
+	}
+	return nil
+}
+
+type envField struct {
+	key   string
+	value reflect.Value
+}
+
+// envFields returns the struct fields with an envconfig tag, nested structs are
+// prefixed with their own tag the same way envconfig does
+func envFields(v reflect.Value, prefix string) []envField {
+	result := []envField{}
+
+	for i := 0; i < v.NumField(); i++ {
+		tag := v.Type().Field(i).Tag.Get("envconfig")
+		if tag == "" || !v.Field(i).CanSet() {
+			continue
+		}
+
+		key := prefix + tag
+		if v.Field(i).Kind() == reflect.Struct {
+			result = append(result, envFields(v.Field(i), key+"_")...)
+			continue
+		}
+		result = append(result, envField{key: key, value: v.Field(i)})
+	}
+
+	return result
+}
+
+func setField(field reflect.Value, value string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(v)
+	case reflect.Int, reflect.Int64:
+		v, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(v)
+	case reflect.Uint, reflect.Uint64:
+		v, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(v)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type %s", field.Type())
+		}
+		values := []string{}
+		if value != "" {
+			values = strings.Split(value, ",")
+		}
+		field.Set(reflect.ValueOf(values))
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+	return nil
+}
+
+// Redacted returns a copy of the config without passwords and tokens
+func (c Config) Redacted() Config {
+	c.DatabaseURL = redactDSN(c.DatabaseURL)
+	c.ReadReplicaDSN = redactDSN(c.ReadReplicaDSN)
+	c.ArchiveEndpoint = redactDSN(c.ArchiveEndpoint)
+	c.RollbarToken = redactValue(c.RollbarToken)
+	c.AdminToken = redactValue(c.AdminToken)
+	return c
+}
+
+// redactDSN removes the password from a connection URL or a key=value connection string
+func redactDSN(dsn string) string {
+	u, err := url.Parse(dsn)
+	if err == nil && u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), redacted)
+			return u.String()
+		}
+		return dsn
+	}
+	return reDSNPassword.ReplaceAllString(dsn, "password="+redacted)
+}
+
+func redactValue(value string) string {
+	if value == "" {
+		return ""
+	}
+	return redacted
+}
@@ -3,10 +3,11 @@ package config
 import "fmt"
 
 var (
-	AppName    = "mina-indexer"
-	AppVersion = "0.9.3"
-	GitCommit  = "-"
-	GoVersion  = "-"
+	AppName        = "mina-indexer"
+	AppVersion     = "0.9.3"
+	IndexerVersion = "-"
+	GitCommit      = "-"
+	GoVersion      = "-"
 )
 
 // VersionString returns the full app version string
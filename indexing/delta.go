@@ -0,0 +1,96 @@
+package indexing
+
+import (
+	"context"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/figment-networks/mina-indexer/client/archive"
+	"github.com/figment-networks/mina-indexer/client/graph"
+	"github.com/figment-networks/mina-indexer/store"
+)
+
+const deltaProgressInterval = 100
+
+// DeltaSync indexes the canonical blocks of the heights missing within the range.
+// Blocks are prepared by the pool of workers and committed in batches of batchSize heights.
+func DeltaSync(db *store.Store, archiveClient archive.Client, graphClient *graph.Client, fromHeight, toHeight uint64, batchSize uint, workers int) error {
+	if batchSize == 0 {
+		batchSize = 1
+	}
+
+	heights, err := db.Blocks.MissingHeights(fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+
+	log.
+		WithField("from", fromHeight).
+		WithField("to", toHeight).
+		WithField("missing", len(heights)).
+		Info("starting delta sync")
+
+	processed := 0
+
+	for i := 0; i < len(heights); i += int(batchSize) {
+		j := i + int(batchSize)
+		if j > len(heights) {
+			j = len(heights)
+		}
+		batch := heights[i:j]
+
+		prepared, err := ParallelPrepare(context.Background(), archiveClient, graphClient, batch, workers)
+		if err != nil {
+			return err
+		}
+
+		found := make([]*Data, 0, len(prepared))
+
+		for idx, data := range prepared {
+			processed++
+			if processed%deltaProgressInterval == 0 {
+				percent := processed * 100 / len(heights)
+				log.
+					WithField("height", batch[idx]).
+					Infof("delta sync [%-20s] %d/%d (%d%%)", strings.Repeat("#", percent/5), processed, len(heights), percent)
+			}
+
+			if data == nil {
+				log.WithField("height", batch[idx]).Warn("no canonical block in archive")
+				continue
+			}
+			found = append(found, data)
+		}
+
+		if err := ImportBatch(db, found); err != nil {
+			return err
+		}
+	}
+
+	log.WithField("processed", processed).Info("delta sync finished")
+	return nil
+}
+
+// PrepareBlock fetches the block data from the nodes and prepares the records
+func PrepareBlock(ctx context.Context, archiveClient archive.Client, graphClient *graph.Client, hash string) (*Data, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	archiveBlock, err := archiveClient.Block(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	graphBlock, err := graphClient.GetBlockWithContext(ctx, hash)
+	if err != nil {
+		if !strings.Contains(err.Error(), "not found in transition frontier") {
+			return nil, err
+		}
+		log.WithError(err).Debug("graph block error")
+		graphBlock = nil
+	}
+
+	return Prepare(archiveBlock, graphBlock)
+}
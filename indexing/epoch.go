@@ -0,0 +1,90 @@
+package indexing
+
+import (
+	"fmt"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/figment-networks/mina-indexer/client/archive"
+	"github.com/figment-networks/mina-indexer/client/graph"
+	"github.com/figment-networks/mina-indexer/model/mapper"
+	"github.com/figment-networks/mina-indexer/store"
+)
+
+// EpochTransitionHandler imports the staking ledger of a new epoch.
+// The GraphQL stakingEpochData field only carries the ledger hash, so ledger
+// entries are fetched from the archive API, which only serves the ledger of
+// the current epoch.
+func EpochTransitionHandler(db *store.Store, graphClient *graph.Client, archiveClient archive.Client, newEpoch int) error {
+	tip, err := graphClient.ConsensusTip()
+	if err != nil {
+		return err
+	}
+
+	var tipEpoch int
+	fmt.Sscanf(tip.ProtocolState.ConsensusState.Epoch, "%d", &tipEpoch)
+
+	if tipEpoch != newEpoch {
+		log.
+			WithField("epoch", newEpoch).
+			WithField("tip_epoch", tipEpoch).
+			Debug("staking ledger is not available for epoch")
+		return nil
+	}
+
+	// Ledger only changes once per epoch
+	currentLedger, err := db.Staking.FindLedger(newEpoch)
+	if err != nil && err != store.ErrNotFound {
+		return err
+	}
+
+	// We already have the epoch ledger, no need to import it
+	if currentLedger != nil && currentLedger.EntriesCount > 0 {
+		count, err := db.Staking.LedgerEntriesCount(currentLedger.ID)
+		if err != nil || count > 0 {
+			return err
+		}
+	}
+
+	log.WithField("epoch", newEpoch).Info("importing staking ledger")
+
+	ledger := mapper.NewLedger(tip)
+	if currentLedger != nil {
+		ledger.ID = currentLedger.ID
+
+		// Clear entries left over by an interrupted import
+		if err := db.Staking.DeleteLedgerEntries(ledger.ID); err != nil {
+			return err
+		}
+	}
+
+	// Entries are imported page by page to keep large ledgers out of memory
+	err = archiveClient.StreamStakingLedger(archive.LedgerTypeCurrent, archive.LedgerPageSize, func(records []archive.StakingInfo) error {
+		if ledger.ID == 0 {
+			if err := db.Staking.CreateLedger(ledger); err != nil {
+				return err
+			}
+		}
+		return db.Staking.CreateLedgerEntries(mapper.LedgerEntries(ledger, records))
+	})
+	if err != nil {
+		if err == archive.ErrNotSupported {
+			log.WithField("epoch", newEpoch).Warn("staking ledger is not available from archive node")
+			return nil
+		}
+		return err
+	}
+
+	// Save the totals accumulated from all pages
+	if ledger.ID == 0 {
+		err = db.Staking.CreateLedger(ledger)
+	} else {
+		err = db.Staking.UpdateLedger(ledger)
+	}
+	if err != nil {
+		return err
+	}
+
+	return db.Validators.UpdateRanks(strconv.Itoa(newEpoch))
+}
@@ -0,0 +1,78 @@
+package indexing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/figment-networks/mina-indexer/client/archive"
+	"github.com/figment-networks/mina-indexer/client/graph"
+	"github.com/figment-networks/mina-indexer/model"
+	"github.com/figment-networks/mina-indexer/store"
+)
+
+// ImportOrRecord imports and finalizes the block data. Failures caused by the block data are
+// recorded in the failed blocks so the height can be inspected and retried later. Other errors,
+// like lost connections or timeouts, are returned as is so the sync cycle retries the block.
+func ImportOrRecord(db *store.Store, data *Data) error {
+	err := Import(db, data)
+	if err == nil {
+		err = Finalize(db, data)
+	}
+	if err == nil {
+		return nil
+	}
+	if !isDataError(err) {
+		return err
+	}
+
+	if recordErr := db.FailedBlocks.Record(data.Block.Height, data.Block.Hash, err); recordErr != nil {
+		log.WithError(recordErr).Error("failed block record error")
+	}
+
+	return fmt.Errorf("%w: %v", ErrImportFailed, err)
+}
+
+// RetryFailedBlock runs the prepare and import pipeline again for the height of the failed block
+// and returns the imported block. The canonical block of the height is used when the archive has one.
+func RetryFailedBlock(db *store.Store, archiveClient archive.Client, graphClient *graph.Client, failed *model.FailedBlock) (*model.Block, error) {
+	hash := failed.Hash
+
+	hashes, err := canonicalHashes(archiveClient, []uint64{failed.Height})
+	if err != nil {
+		return nil, err
+	}
+	if canonicalHash, ok := hashes[failed.Height]; ok {
+		hash = canonicalHash
+	}
+
+	data, err := PrepareBlock(context.Background(), archiveClient, graphClient, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ImportOrRecord(db, data); err != nil {
+		return nil, err
+	}
+
+	return data.Block, db.FailedBlocks.Resolve(failed.Height)
+}
+
+// isDataError returns true if the database rejected the block data,
+// e.g. with an invalid value or a constraint violation
+func isDataError(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+
+	switch pqErr.Code.Class() {
+	case "22", "23":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,30 @@
+package indexing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsDataError(t *testing.T) {
+	examples := []struct {
+		err      error
+		expected bool
+	}{
+		{err: &pq.Error{Code: "22003"}, expected: true},
+		{err: &pq.Error{Code: "23505"}, expected: true},
+		{err: fmt.Errorf("import: %w", &pq.Error{Code: "23502"}), expected: true},
+		{err: &pq.Error{Code: "57P01"}, expected: false},
+		{err: &pq.Error{Code: "40001"}, expected: false},
+		{err: context.DeadlineExceeded, expected: false},
+		{err: errors.New("driver: bad connection"), expected: false},
+	}
+
+	for _, ex := range examples {
+		assert.Equal(t, ex.expected, isDataError(ex.err), ex.err.Error())
+	}
+}
@@ -1,12 +1,34 @@
 package indexing
 
 import (
+	"errors"
+
 	log "github.com/sirupsen/logrus"
 
 	"github.com/figment-networks/mina-indexer/model"
 	"github.com/figment-networks/mina-indexer/store"
 )
 
+var (
+	// ErrAlreadyIndexed is returned when a canonical block already exists at a height
+	ErrAlreadyIndexed = errors.New("height is already indexed")
+
+	// ErrImportFailed is returned when the block data could not be stored
+	ErrImportFailed = errors.New("block import failed")
+)
+
+// AlreadyIndexed returns true if a canonical block is already indexed at the height
+func AlreadyIndexed(db *store.Store, height uint64) (bool, error) {
+	_, err := db.Blocks.FindByHeight(height)
+	if err != nil {
+		if err == store.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
 // Import creates new database records for the chain data
 func Import(db *store.Store, data *Data) error {
 	log.Debug("creating block")
@@ -56,3 +78,38 @@ func Import(db *store.Store, data *Data) error {
 
 	return nil
 }
+
+// ImportBatch imports and finalizes the blocks within a single transaction. When the
+// transaction fails the blocks are imported one by one so the failing block gets recorded.
+func ImportBatch(db *store.Store, batch []*Data) error {
+	err := db.Transaction(func(tx *store.Store) error {
+		for _, data := range batch {
+			if err := Import(tx, data); err != nil {
+				return err
+			}
+			if err := Finalize(tx, data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err == nil {
+		return nil
+	}
+
+	log.WithError(err).WithField("count", len(batch)).Warn("batch import failed, importing blocks one by one")
+
+	for _, data := range batch {
+		// Block ID might be assigned by the rolled back transaction
+		data.Block.ID = 0
+
+		if err := ImportOrRecord(db, data); err != nil {
+			if !errors.Is(err, ErrImportFailed) {
+				return err
+			}
+			log.WithError(err).WithField("height", data.Block.Height).Error("block recorded as failed")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package indexing
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"github.com/figment-networks/mina-indexer/client/archive"
+	"github.com/figment-networks/mina-indexer/store"
+)
+
+// DetectOrphans compares the indexed blocks starting at the given height against
+// the archive node canonical chain and marks the diverged blocks as orphaned.
+// It returns the hashes of the newly orphaned blocks.
+func DetectOrphans(db *store.Store, archiveClient archive.Client, fromHeight uint64) ([]string, error) {
+	localBlocks, err := db.Blocks.FindUnsafeBlocks(fromHeight)
+	if err != nil {
+		return nil, err
+	}
+	if len(localBlocks) == 0 {
+		return nil, nil
+	}
+
+	canonical := true
+	remoteBlocks, err := archiveClient.Blocks(&archive.BlocksRequest{
+		Canonical:   &canonical,
+		StartHeight: uint(fromHeight),
+		Limit:       uint(localBlocks[len(localBlocks)-1].Height-fromHeight) + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	canonicalHashes := map[uint64]string{}
+	for _, block := range remoteBlocks {
+		canonicalHashes[block.Height] = block.StateHash
+	}
+
+	orphaned := []string{}
+	for _, block := range localBlocks {
+		hash, ok := canonicalHashes[block.Height]
+		if !ok || hash == block.Hash || block.Orphaned {
+			continue
+		}
+
+		log.
+			WithField("height", block.Height).
+			WithField("hash", block.Hash).
+			Info("marking block as orphaned")
+
+		if err := db.Blocks.MarkOrphaned(block.Hash); err != nil {
+			return nil, err
+		}
+		orphaned = append(orphaned, block.Hash)
+	}
+
+	return orphaned, nil
+}
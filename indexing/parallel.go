@@ -0,0 +1,133 @@
+package indexing
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/figment-networks/mina-indexer/client/archive"
+	"github.com/figment-networks/mina-indexer/client/graph"
+)
+
+// ParallelPrepare prepares the canonical blocks at the heights using a pool of workers.
+// Results are returned in the heights order, with nil entries for heights missing in the archive.
+func ParallelPrepare(ctx context.Context, archiveClient archive.Client, graphClient *graph.Client, heights []uint64, workers int) ([]*Data, error) {
+	result := make([]*Data, len(heights))
+	if len(heights) == 0 {
+		return result, nil
+	}
+
+	hashes, err := canonicalHashes(archiveClient, heights)
+	if err != nil {
+		return nil, err
+	}
+
+	found := []string{}
+	positions := []int{}
+	for idx, height := range heights {
+		if hash, ok := hashes[height]; ok {
+			found = append(found, hash)
+			positions = append(positions, idx)
+		}
+	}
+
+	prepared, err := ParallelPrepareHashes(ctx, archiveClient, graphClient, found, workers)
+	if err != nil {
+		return nil, err
+	}
+	for idx, data := range prepared {
+		result[positions[idx]] = data
+	}
+
+	return result, nil
+}
+
+// ParallelPrepareHashes prepares the blocks with the state hashes using a pool of workers.
+// Results are returned in the hashes order.
+func ParallelPrepareHashes(ctx context.Context, archiveClient archive.Client, graphClient *graph.Client, hashes []string, workers int) ([]*Data, error) {
+	result := make([]*Data, len(hashes))
+	if len(hashes) == 0 {
+		return result, nil
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan int)
+	errs := make(chan error, workers)
+	wg := &sync.WaitGroup{}
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for idx := range jobs {
+				data, err := PrepareBlock(ctx, archiveClient, graphClient, hashes[idx])
+				if err != nil {
+					errs <- err
+					cancel()
+					return
+				}
+				result[idx] = data
+			}
+		}()
+	}
+
+feed:
+	for idx := range hashes {
+		select {
+		case jobs <- idx:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	select {
+	case err := <-errs:
+		return nil, err
+	default:
+	}
+
+	return result, ctx.Err()
+}
+
+// canonicalHashes returns the canonical block hashes of the heights found in the archive.
+// Heights are fetched in contiguous runs so sparse gaps do not pull the blocks in between.
+func canonicalHashes(archiveClient archive.Client, heights []uint64) (map[uint64]string, error) {
+	sorted := make([]uint64, len(heights))
+	copy(sorted, heights)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	hashes := map[uint64]string{}
+	canonical := true
+
+	for i := 0; i < len(sorted); {
+		j := i + 1
+		for j < len(sorted) && sorted[j] <= sorted[j-1]+1 {
+			j++
+		}
+
+		blocks, err := archiveClient.Blocks(&archive.BlocksRequest{
+			StartHeight: uint(sorted[i]),
+			Limit:       uint(sorted[j-1]-sorted[i]) + 1,
+			Canonical:   &canonical,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, block := range blocks {
+			hashes[block.Height] = block.StateHash
+		}
+		i = j
+	}
+
+	return hashes, nil
+}
@@ -1,10 +1,14 @@
 package indexing
 
 import (
+	log "github.com/sirupsen/logrus"
+
 	"github.com/figment-networks/mina-indexer/client/archive"
 	"github.com/figment-networks/mina-indexer/client/graph"
+	"github.com/figment-networks/mina-indexer/model"
 	"github.com/figment-networks/mina-indexer/model/mapper"
 	"github.com/figment-networks/mina-indexer/model/types"
+	"github.com/figment-networks/mina-indexer/model/util"
 )
 
 // Prepare generates a new models from the graph block data
@@ -14,8 +18,14 @@ func Prepare(archiveBlock *archive.Block, graphBlock *graph.Block) (*Data, error
 		return nil, err
 	}
 
+	fillMissingSlot(block, graphBlock)
+
 	if graphBlock != nil {
 		block.TotalCurrency = types.NewAmount(graphBlock.ProtocolState.ConsensusState.TotalCurrency)
+
+		if block.BlockProducerVRFOutput == "" {
+			block.BlockProducerVRFOutput = graphBlock.ProtocolState.ConsensusState.LastVrfOutput
+		}
 	}
 
 	// Prepare validator record
@@ -48,9 +58,17 @@ func Prepare(archiveBlock *archive.Block, graphBlock *graph.Block) (*Data, error
 	if err != nil {
 		return nil, err
 	}
+	snarkJobs = dedupSnarkJobs(snarkJobs)
 	block.SnarkJobsCount = len(snarkJobs)
 	block.SnarkJobsFees = types.NewInt64Amount(0)
 	for _, job := range snarkJobs {
+		if job.Fee.IsNil() {
+			log.
+				WithField("block", job.BlockHash).
+				WithField("prover", job.Prover).
+				Warn("skipping snark job without fee")
+			continue
+		}
 		block.SnarkJobsFees = block.SnarkJobsFees.Add(job.Fee)
 	}
 
@@ -71,3 +89,58 @@ func Prepare(archiveBlock *archive.Block, graphBlock *graph.Block) (*Data, error
 
 	return data, nil
 }
+
+// dedupSnarkJobs removes the jobs repeated by the same prover for the same works
+func dedupSnarkJobs(jobs []model.SnarkJob) []model.SnarkJob {
+	type jobKey struct {
+		prover      string
+		workIDsHash string
+	}
+
+	seen := map[jobKey]bool{}
+	result := make([]model.SnarkJob, 0, len(jobs))
+
+	for _, job := range jobs {
+		key := jobKey{job.Prover, job.WorkIDsHash}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, job)
+	}
+
+	if removed := len(jobs) - len(result); removed > 0 {
+		log.
+			WithField("block", jobs[0].BlockHash).
+			WithField("count", removed).
+			Info("removed duplicate snark jobs")
+	}
+
+	return result
+}
+
+// fillMissingSlot derives the block slot and epoch from the graph consensus state.
+// Older archive versions report a zero global slot when it is missing, only the
+// genesis block really has slot 0.
+func fillMissingSlot(block *model.Block, graphBlock *graph.Block) {
+	if block.Slot != 0 || block.Height <= 1 {
+		return
+	}
+	if graphBlock == nil || graphBlock.ProtocolState == nil || graphBlock.ProtocolState.ConsensusState == nil {
+		return
+	}
+
+	state := graphBlock.ProtocolState.ConsensusState
+
+	epoch, err := util.ParseInt(state.Epoch)
+	if err != nil {
+		return
+	}
+	slot, err := util.ParseInt(state.Slot)
+	if err != nil {
+		return
+	}
+
+	block.Epoch = epoch
+	block.Slot = epoch*model.SlotsPerEpoch + slot%model.SlotsPerEpoch
+}
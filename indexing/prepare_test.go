@@ -0,0 +1,44 @@
+package indexing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/figment-networks/mina-indexer/client/graph"
+	"github.com/figment-networks/mina-indexer/model"
+)
+
+func TestFillMissingSlot(t *testing.T) {
+	graphBlock := func(epoch, slot string) *graph.Block {
+		return &graph.Block{
+			ProtocolState: &graph.ProtocolState{
+				ConsensusState: &graph.ConsensusState{Epoch: epoch, Slot: slot},
+			},
+		}
+	}
+
+	examples := []struct {
+		name          string
+		block         model.Block
+		graphBlock    *graph.Block
+		expectedSlot  int
+		expectedEpoch int
+	}{
+		{"genesis", model.Block{Height: 1}, graphBlock("0", "0"), 0, 0},
+		{"missing slot", model.Block{Height: 7500}, graphBlock("1", "365"), 7505, 1},
+		{"existing slot", model.Block{Height: 7500, Slot: 7505, Epoch: 1}, graphBlock("2", "1"), 7505, 1},
+		{"missing graph block", model.Block{Height: 7500}, nil, 0, 0},
+		{"invalid consensus state", model.Block{Height: 7500}, graphBlock("", ""), 0, 0},
+	}
+
+	for _, example := range examples {
+		t.Run(example.name, func(t *testing.T) {
+			block := example.block
+			fillMissingSlot(&block, example.graphBlock)
+
+			assert.Equal(t, example.expectedSlot, block.Slot)
+			assert.Equal(t, example.expectedEpoch, block.Epoch)
+		})
+	}
+}
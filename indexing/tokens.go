@@ -0,0 +1,25 @@
+package indexing
+
+import (
+	"context"
+
+	"github.com/figment-networks/mina-indexer/client/graph"
+	"github.com/figment-networks/mina-indexer/model/mapper"
+	"github.com/figment-networks/mina-indexer/store"
+)
+
+// ImportTokenBalances refreshes the custom token balances of the accounts with the
+// current balances from the node
+func ImportTokenBalances(ctx context.Context, db *store.Store, graphClient *graph.Client, publicKeys []string) error {
+	accounts := []graph.Account{}
+
+	for _, key := range publicKeys {
+		tokens, err := graphClient.GetAccountTokens(ctx, key)
+		if err != nil {
+			return err
+		}
+		accounts = append(accounts, tokens...)
+	}
+
+	return db.Accounts.ImportTokenBalances(mapper.TokenBalances(accounts))
+}
@@ -20,8 +20,32 @@ type Account struct {
 	StartTime      time.Time    `json:"start_time"`
 	LastHeight     uint64       `json:"last_height"`
 	LastTime       time.Time    `json:"last_time"`
-	CreatedAt      time.Time    `json:"-"`
-	UpdatedAt      time.Time    `json:"-"`
+
+	TimingInitialMinimumBalance types.Amount `json:"initial_minimum_balance"`
+	TimingCliffTime             *int         `json:"cliff_time"`
+	TimingCliffAmount           types.Amount `json:"cliff_amount"`
+	TimingVestingPeriod         *int         `json:"vesting_period"`
+	TimingVestingIncrement      types.Amount `json:"vesting_increment"`
+
+	Tokens    []TokenBalance `json:"tokens,omitempty" gorm:"-"`
+	CreatedAt time.Time      `json:"-"`
+	UpdatedAt time.Time      `json:"-"`
+}
+
+// TokenBalance contains the account balance of a custom token
+type TokenBalance struct {
+	ID          int          `json:"-"`
+	PublicKey   string       `json:"-"`
+	TokenID     string       `json:"token_id"`
+	TokenSymbol *string      `json:"token_symbol"`
+	Balance     types.Amount `json:"balance"`
+	CreatedAt   time.Time    `json:"-"`
+	UpdatedAt   time.Time    `json:"-"`
+}
+
+// TableName returns the model table name
+func (TokenBalance) TableName() string {
+	return "account_tokens"
 }
 
 // String returns account text representation
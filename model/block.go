@@ -17,6 +17,7 @@ type Block struct {
 	ParentHash        string         `json:"parent_hash"`
 	Time              time.Time      `json:"time"`
 	Canonical         bool           `json:"canonical"`
+	Orphaned          bool           `json:"orphaned"`
 	LedgerHash        string         `json:"ledger_hash"`
 	SnarkedLedgerHash string         `json:"snarked_ledger_hash"`
 	Creator           string         `json:"creator"`
@@ -30,6 +31,13 @@ type Block struct {
 	SnarkerAccounts   pq.StringArray `json:"snarker_accounts"`
 	SnarkJobsCount    int            `json:"snark_jobs_count"`
 	SnarkJobsFees     types.Amount   `json:"snark_jobs_fees"`
+	PendingTxCount    *int           `json:"pending_tx_count"`
+
+	// Command counts by kind, user commands include zkapp commands
+	UserCommandsCount     int `json:"user_commands_count"`
+	InternalCommandsCount int `json:"internal_commands_count"`
+
+	BlockProducerVRFOutput string `json:"block_producer_vrf_output" gorm:"column:block_producer_vrf_output"`
 }
 
 // BlockIntervalStat contains block count stats for a given time interval
@@ -41,13 +49,18 @@ type BlockIntervalStat struct {
 
 // BlockAvgStat contains block averagess
 type BlockAvgStat struct {
-	StartHeight int64   `json:"start_height"`
-	EndHeight   int64   `json:"end_height"`
-	StartTime   string  `json:"start_time"`
-	EndTime     string  `json:"end_time"`
-	Count       int64   `json:"count"`
-	Diff        float64 `json:"diff"`
-	Avg         float64 `json:"avg"`
+	StartHeight int64     `json:"start_height"`
+	EndHeight   int64     `json:"end_height"`
+	StartTime   string    `json:"start_time"`
+	EndTime     string    `json:"end_time"`
+	Timestamp   time.Time `json:"timestamp"`
+	Count       int64     `json:"count"`
+	Diff        float64   `json:"diff"`
+	Avg         float64   `json:"avg"`
+	P50Seconds  float64   `json:"p50_seconds"`
+	P95Seconds  float64   `json:"p95_seconds"`
+	P99Seconds  float64   `json:"p99_seconds"`
+	SampleSize  int64     `json:"sample_size"`
 }
 
 // TableName returns the model table name
@@ -77,3 +90,10 @@ func (b Block) Validate() error {
 	}
 	return nil
 }
+
+// BlockTimePoint contains the average block time of a single time bucket
+type BlockTimePoint struct {
+	Timestamp  time.Time `json:"timestamp"`
+	AvgSeconds float64   `json:"avg_seconds"`
+	BlockCount int       `json:"block_count"`
+}
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"time"
+
 	"github.com/figment-networks/mina-indexer/model/types"
 )
 
@@ -9,3 +11,20 @@ type Delegation struct {
 	Delegate  string       `json:"delegate"`
 	Balance   types.Amount `json:"balance"`
 }
+
+// DelegationChange contains a single delegation transaction of an account
+type DelegationChange struct {
+	Hash        string    `json:"hash"`
+	Delegate    string    `json:"delegate"`
+	BlockHeight uint64    `json:"block_height"`
+	BlockHash   string    `json:"block_hash"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// DelegationDiff contains the delegators changes of a validator between two epochs
+type DelegationDiff struct {
+	Epoch          int          `json:"epoch"`
+	Joined         []string     `json:"joined"`
+	Left           []string     `json:"left"`
+	NetStakeChange types.Amount `json:"net_stake_change"`
+}
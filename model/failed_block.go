@@ -0,0 +1,15 @@
+package model
+
+import (
+	"time"
+)
+
+// FailedBlock contains a block height that could not be imported
+type FailedBlock struct {
+	Height    uint64    `json:"height"`
+	Hash      string    `json:"hash"`
+	Error     string    `json:"error"`
+	Attempts  int       `json:"attempts"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
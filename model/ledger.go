@@ -37,3 +37,17 @@ type LedgerEntry struct {
 func (LedgerEntry) TableName() string {
 	return "ledger_entries"
 }
+
+// DelegatedStake contains the total ledger balance and the balance delegated to a validator
+type DelegatedStake struct {
+	Total     types.Amount `json:"total"`
+	Delegated types.Amount `json:"delegated"`
+}
+
+// StakingHistoryEntry contains the staking ledger state of an account in an epoch
+type StakingHistoryEntry struct {
+	Epoch                       int          `json:"epoch"`
+	Balance                     types.Amount `json:"balance"`
+	Delegate                    string       `json:"delegate"`
+	TimingInitialMinimumBalance types.Amount `json:"timing_initial_minimum_balance"`
+}
@@ -31,9 +31,35 @@ func Account(block *graph.Block, input *graph.Account) (*model.Account, error) {
 		acc.Nonce = util.MustUInt64(*input.Nonce)
 	}
 
+	if timing := input.Timing; timing != nil {
+		acc.TimingInitialMinimumBalance = optionalAmount(timing.InitialMinimumBalance)
+		acc.TimingCliffTime = optionalInt(timing.CliffTime)
+		acc.TimingCliffAmount = optionalAmount(timing.CliffAmount)
+		acc.TimingVestingPeriod = optionalInt(timing.VestingPeriod)
+		acc.TimingVestingIncrement = optionalAmount(timing.VestingIncrement)
+	}
+
 	return acc, acc.Validate()
 }
 
+func optionalAmount(input *string) types.Amount {
+	if input == nil {
+		return types.Amount{}
+	}
+	return types.NewAmount(*input)
+}
+
+func optionalInt(input *string) *int {
+	if input == nil {
+		return nil
+	}
+	val, err := util.ParseInt(*input)
+	if err != nil {
+		return nil
+	}
+	return &val
+}
+
 // Accounts returns accounts models references from the block data
 func Accounts(block *graph.Block) ([]model.Account, error) {
 	if block == nil {
@@ -77,6 +103,7 @@ func AccountFromStagedLedger(block *graph.Block, entry *archive.StakingInfo) (*m
 		PublicKey:      entry.Pk,
 		Balance:        types.NewFloatAmount(entry.Balance),
 		BalanceUnknown: types.NewFloatAmount(entry.Balance),
+		Nonce:          util.MustUInt64(entry.Nonce),
 		StartHeight:    height,
 		StartTime:      time,
 		LastHeight:     height,
@@ -90,5 +117,16 @@ func AccountFromStagedLedger(block *graph.Block, entry *archive.StakingInfo) (*m
 		account.Delegate = nil
 	}
 
+	if timing := entry.Timing; timing != nil {
+		cliffTime, _ := util.ParseInt(timing.CliffTime)
+		vestingPeriod, _ := util.ParseInt(timing.VestingPeriod)
+
+		account.TimingInitialMinimumBalance = types.NewFloatAmount(timing.InitialMinimumBalance)
+		account.TimingCliffTime = &cliffTime
+		account.TimingCliffAmount = types.NewFloatAmount(timing.CliffAmount)
+		account.TimingVestingPeriod = &vestingPeriod
+		account.TimingVestingIncrement = types.NewFloatAmount(timing.VestingIncrement)
+	}
+
 	return account, nil
 }
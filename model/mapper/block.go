@@ -18,9 +18,13 @@ func BlockFromArchive(input *archive.Block) (*model.Block, error) {
 		ParentHash:        input.ParentHash,
 		LedgerHash:        input.LedgerHash,
 		SnarkedLedgerHash: input.SnarkedLedgerHash,
-		Epoch:             int(input.GlobalSlot) / 7140,
+		Epoch:             int(input.GlobalSlot) / model.SlotsPerEpoch,
 		Slot:              int(input.GlobalSlot),
 		TransactionsCount: len(input.UserCommands) + len(input.InternalCommands),
+
+		UserCommandsCount:      len(input.UserCommands) + len(input.ZkappCommands),
+		InternalCommandsCount:  len(input.InternalCommands),
+		BlockProducerVRFOutput: input.LastVrfOutput,
 	}
 
 	for _, cmd := range input.InternalCommands {
@@ -23,14 +23,28 @@ func (data *LedgerData) UpdateLedgerID() {
 }
 
 func Ledger(tip *graph.Block, records []archive.StakingInfo) (*LedgerData, error) {
+	ledgerRecord := NewLedger(tip)
+
+	return &LedgerData{
+		Ledger:  ledgerRecord,
+		Entries: LedgerEntries(ledgerRecord, records),
+	}, nil
+}
+
+// NewLedger returns an empty ledger record for the epoch of the tip block
+func NewLedger(tip *graph.Block) *model.Ledger {
 	ledgerRecord := &model.Ledger{
-		EntriesCount:      len(records),
 		Time:              time.Now(),
 		DelegationsAmount: types.NewInt64Amount(0),
 		StakedAmount:      types.NewInt64Amount(0),
 	}
 	fmt.Sscanf(tip.ProtocolState.ConsensusState.Epoch, "%d", &ledgerRecord.Epoch)
 
+	return ledgerRecord
+}
+
+// LedgerEntries maps the staking ledger records and adds them to the ledger totals
+func LedgerEntries(ledgerRecord *model.Ledger, records []archive.StakingInfo) []model.LedgerEntry {
 	entries := []model.LedgerEntry{}
 
 	for _, record := range records {
@@ -46,6 +60,7 @@ func Ledger(tip *graph.Block, records []archive.StakingInfo) (*LedgerData, error
 			TimingCliffAmount:           types.Amount{},
 		}
 
+		ledgerRecord.EntriesCount++
 		ledgerRecord.StakedAmount = ledgerRecord.StakedAmount.Add(balance)
 
 		if entry.Delegation {
@@ -68,8 +83,5 @@ func Ledger(tip *graph.Block, records []archive.StakingInfo) (*LedgerData, error
 		entries = append(entries, entry)
 	}
 
-	return &LedgerData{
-		Ledger:  ledgerRecord,
-		Entries: entries,
-	}, nil
+	return entries
 }
@@ -1,9 +1,12 @@
 package mapper
 
 import (
+	"fmt"
+
 	"github.com/figment-networks/mina-indexer/client/graph"
 	"github.com/figment-networks/mina-indexer/model"
 	"github.com/figment-networks/mina-indexer/model/types"
+	"github.com/figment-networks/mina-indexer/model/util"
 )
 
 // SnarkJob returns a job model constructed from the graph input
@@ -15,6 +18,8 @@ func SnarkJob(block *graph.Block, w *graph.CompletedWork) (*model.SnarkJob, erro
 		Prover:     w.Prover,
 		Fee:        types.NewAmount(w.Fee),
 		WorksCount: len(w.WorkIds),
+
+		WorkIDsHash: util.SHA1(fmt.Sprint(w.WorkIds)),
 	}
 	return j, j.Validate()
 }
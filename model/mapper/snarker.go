@@ -11,9 +11,13 @@ func Snarker(block *graph.Block, job *graph.CompletedWork) (*model.Snarker, erro
 	height := BlockHeight(block)
 	time := BlockTime(block)
 
+	fee := util.MustUInt64(job.Fee)
+
 	snarker := &model.Snarker{
 		Account:     job.Prover,
-		Fee:         util.MustUInt64(job.Fee),
+		Fee:         fee,
+		FeeTotal:    fee,
+		AvgFee:      fee,
 		JobsCount:   1,
 		WorksCount:  len(job.WorkIds),
 		StartHeight: height,
@@ -41,8 +45,11 @@ func Snarkers(block *graph.Block) ([]model.Snarker, error) {
 			}
 			snarkers[job.Prover] = s
 		} else {
-			snarkers[job.Prover].JobsCount++
-			snarkers[job.Prover].WorksCount += len(job.WorkIds)
+			s := snarkers[job.Prover]
+			s.JobsCount++
+			s.WorksCount += len(job.WorkIds)
+			s.FeeTotal += util.MustUInt64(job.Fee)
+			s.AvgFee = s.FeeTotal / uint64(s.JobsCount)
 		}
 	}
 
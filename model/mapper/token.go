@@ -0,0 +1,38 @@
+package mapper
+
+import (
+	"github.com/figment-networks/mina-indexer/client/graph"
+	"github.com/figment-networks/mina-indexer/model"
+	"github.com/figment-networks/mina-indexer/model/types"
+)
+
+// defaultTokenIDs contains the MINA token ID used by the legacy and current networks
+var defaultTokenIDs = map[string]bool{
+	"1": true,
+	"wSHV2S4qX9jFsLjQo8r1BsMLH2ZRKsZx6EJd1sbozGPieEC4Jf": true,
+}
+
+// TokenBalances returns the custom token balances of the graph accounts
+func TokenBalances(accounts []graph.Account) []model.TokenBalance {
+	result := []model.TokenBalance{}
+
+	for _, acc := range accounts {
+		if acc.TokenID == "" || defaultTokenIDs[acc.TokenID] {
+			continue
+		}
+
+		balance := types.NewInt64Amount(0)
+		if acc.Balance != nil {
+			balance = types.NewAmount(acc.Balance.Total)
+		}
+
+		result = append(result, model.TokenBalance{
+			PublicKey:   acc.PublicKey,
+			TokenID:     acc.TokenID,
+			TokenSymbol: acc.TokenSymbol,
+			Balance:     balance,
+		})
+	}
+
+	return result
+}
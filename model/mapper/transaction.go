@@ -15,15 +15,15 @@ func UserTransaction(block *graph.Block, t *graph.UserCommand) (*model.Transacti
 		ttype = model.TxTypeDelegation
 	}
 
-	var memoText *string
-	if text := util.ParseMemoText(t.Memo); len(text) > 0 {
-		memoText = &text
-	}
+	memoText, memoHash := memoFields(t.Memo)
+
+	blockTime := BlockTime(block)
 
 	tran := &model.Transaction{
 		Type:        ttype,
 		Hash:        t.Hash,
-		Time:        BlockTime(block),
+		Time:        blockTime,
+		Timestamp:   &blockTime,
 		BlockHeight: BlockHeight(block),
 		BlockHash:   block.StateHash,
 		Sender:      &t.From,
@@ -32,11 +32,48 @@ func UserTransaction(block *graph.Block, t *graph.UserCommand) (*model.Transacti
 		Fee:         types.NewAmount(t.Fee),
 		Nonce:       &t.Nonce,
 		Memo:        memoText,
+		MemoHash:    memoHash,
 	}
 
 	return tran, tran.Validate()
 }
 
+// memoFields returns the decoded memo text and its SHA256 digest
+func memoFields(memo string) (*string, *string) {
+	text := util.ParseMemoText(memo)
+	if len(text) == 0 {
+		return nil, nil
+	}
+
+	hash := util.SHA256(text)
+	return &text, &hash
+}
+
+// PendingTransaction returns a transaction model for the pooled user command
+func PendingTransaction(t *graph.PendingTransaction) *model.Transaction {
+	ttype := model.TxTypePayment
+	if t.IsDelegation {
+		ttype = model.TxTypeDelegation
+	}
+
+	var memoText *string
+	if len(t.Memo) > 0 {
+		memoText = &t.Memo
+	}
+
+	return &model.Transaction{
+		Type:     ttype,
+		Hash:     t.Hash,
+		Sender:   &t.From,
+		Receiver: t.To,
+		Amount:   types.NewAmount(t.Amount),
+		Fee:      types.NewAmount(t.Fee),
+		Nonce:    &t.Nonce,
+		Memo:     memoText,
+		Status:   model.TxStatusPending,
+	}
+}
+
 func BlockRewardTransaction(block *graph.Block) (*model.Transaction, error) {
 	t := &model.Transaction{
 		Type:        model.TxTypeCoinbase,
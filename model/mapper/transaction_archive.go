@@ -1,6 +1,7 @@
 package mapper
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/figment-networks/mina-indexer/client/archive"
@@ -12,7 +13,7 @@ import (
 func TransactionsFromArchive(block *archive.Block) ([]model.Transaction, error) {
 	blockHeight := uint64(block.Height)
 	blockTime := time.Unix(block.Timestamp/1000, 0)
-	result := make([]model.Transaction, len(block.UserCommands)+len(block.InternalCommands))
+	result := make([]model.Transaction, len(block.UserCommands)+len(block.InternalCommands)+len(block.ZkappCommands))
 	idx := 0
 
 	for _, cmd := range block.InternalCommands {
@@ -22,6 +23,7 @@ func TransactionsFromArchive(block *archive.Block) ([]model.Transaction, error)
 			BlockHash:               block.StateHash,
 			BlockHeight:             blockHeight,
 			Time:                    blockTime,
+			Timestamp:               &blockTime,
 			Receiver:                cmd.Receiver,
 			Amount:                  types.NewInt64Amount(cmd.Fee),
 			Status:                  model.TxStatusApplied,
@@ -34,9 +36,12 @@ func TransactionsFromArchive(block *archive.Block) ([]model.Transaction, error)
 	for _, cmd := range block.UserCommands {
 		sender := cmd.Sender
 
-		var memoText *string
-		if text := util.ParseMemoText(cmd.Memo); len(text) > 0 {
-			memoText = &text
+		memoText, memoHash := memoFields(cmd.Memo)
+
+		// Use the command timestamp reported by the archive when available
+		timestamp := blockTime
+		if cmd.Timestamp != nil {
+			timestamp = time.Unix(0, *cmd.Timestamp*int64(time.Millisecond))
 		}
 
 		result[idx] = model.Transaction{
@@ -45,6 +50,7 @@ func TransactionsFromArchive(block *archive.Block) ([]model.Transaction, error)
 			BlockHash:      block.StateHash,
 			BlockHeight:    blockHeight,
 			Time:           blockTime,
+			Timestamp:      &timestamp,
 			Sender:         &sender,
 			Receiver:       cmd.Receiver,
 			Amount:         types.NewInt64Amount(cmd.Amount),
@@ -54,6 +60,50 @@ func TransactionsFromArchive(block *archive.Block) ([]model.Transaction, error)
 			SequenceNumber: &cmd.SequenceNo,
 			Nonce:          &cmd.Nonce,
 			Memo:           memoText,
+			MemoHash:       memoHash,
+		}
+		idx++
+	}
+
+	for _, cmd := range block.ZkappCommands {
+		feePayer := cmd.FeePayer
+		sequenceNo := cmd.SequenceNo
+		nonce := cmd.Nonce
+
+		memoText, memoHash := memoFields(cmd.Memo)
+
+		body := &model.ZkAppBody{
+			AccountUpdates: make([]model.AccountUpdate, len(cmd.AccountUpdates)),
+		}
+		for i, update := range cmd.AccountUpdates {
+			body.AccountUpdates[i] = model.AccountUpdate{
+				PublicKey:      update.PublicKey,
+				TokenID:        update.TokenID,
+				BalanceChange:  strconv.FormatInt(update.BalanceChange, 10),
+				IncrementNonce: update.IncrementNonce,
+				CallDepth:      update.CallDepth,
+			}
+		}
+
+		// zkApp commands have no single receiver, fee payer is used instead
+		result[idx] = model.Transaction{
+			Type:           model.TxTypeZkApp,
+			Hash:           cmd.Hash,
+			BlockHash:      block.StateHash,
+			BlockHeight:    blockHeight,
+			Time:           blockTime,
+			Timestamp:      &blockTime,
+			Sender:         &feePayer,
+			Receiver:       feePayer,
+			Amount:         types.NewInt64Amount(0),
+			Fee:            types.NewInt64Amount(cmd.Fee),
+			Status:         cmd.Status,
+			FailureReason:  cmd.FailureReason,
+			SequenceNumber: &sequenceNo,
+			Nonce:          &nonce,
+			Memo:           memoText,
+			MemoHash:       memoHash,
+			ZkAppBody:      body,
 		}
 		idx++
 	}
@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+)
+
+// Metadata contains a single indexer setting persisted in the database
+type Metadata struct {
+	Key       string    `json:"key"`
+	Value     string    `json:"value"`
+	CreatedAt time.Time `json:"-"`
+	UpdatedAt time.Time `json:"-"`
+}
+
+// TableName returns the model table name
+func (Metadata) TableName() string {
+	return "metadata"
+}
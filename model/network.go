@@ -0,0 +1,23 @@
+package model
+
+import (
+	"github.com/figment-networks/mina-indexer/model/types"
+)
+
+// NetworkSummary contains the network wide totals
+type NetworkSummary struct {
+	TotalAccounts     int64        `json:"total_accounts"`
+	TotalTransactions int64        `json:"total_transactions"`
+	TotalStaked       types.Amount `json:"total_staked"`
+	LatestEpoch       int          `json:"latest_epoch"`
+	TotalSnarkJobs    int64        `json:"total_snark_jobs"`
+	TotalRewardsPaid  types.Amount `json:"total_rewards_paid"`
+}
+
+// DayVolume contains the canonical transactions totals of a single day
+type DayVolume struct {
+	Date        string       `json:"date"`
+	TxCount     int64        `json:"tx_count"`
+	TotalAmount types.Amount `json:"total_amount"`
+	TotalFees   types.Amount `json:"total_fees"`
+}
@@ -17,6 +17,9 @@ type SnarkJob struct {
 	Fee        types.Amount `json:"fee"`
 	WorksCount int          `json:"works_count"`
 	CreatedAt  time.Time    `json:"-"`
+
+	// WorkIDsHash identifies the set of works included in the job, it's not persisted
+	WorkIDsHash string `json:"-" gorm:"-"`
 }
 
 // TableName returns the Job table name
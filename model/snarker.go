@@ -3,12 +3,16 @@ package model
 import (
 	"errors"
 	"time"
+
+	"github.com/figment-networks/mina-indexer/model/types"
 )
 
 type Snarker struct {
 	ID          int       `json:"-"`
 	Account     string    `json:"public_key"`
 	Fee         uint64    `json:"fee"`
+	FeeTotal    uint64    `json:"fee_total"`
+	AvgFee      uint64    `json:"avg_fee"`
 	JobsCount   int       `json:"jobs_count"`
 	WorksCount  int       `json:"works_count"`
 	StartHeight uint64    `json:"start_time"`
@@ -25,3 +29,11 @@ func (s Snarker) Validate() error {
 	}
 	return nil
 }
+
+// BlockSnarker contains the SNARK work summary of a prover in a single block
+type BlockSnarker struct {
+	PublicKey  string       `json:"public_key"`
+	JobsCount  int          `json:"jobs_count"`
+	WorksCount int          `json:"works_count"`
+	FeeTotal   types.Amount `json:"fee_total"`
+}
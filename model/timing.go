@@ -0,0 +1,99 @@
+package model
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/figment-networks/mina-indexer/model/types"
+)
+
+const (
+	// SlotsPerEpoch is the number of slots in a single epoch
+	SlotsPerEpoch = 7140
+
+	// SlotDuration is the duration of a single slot
+	SlotDuration = time.Minute * 3
+
+	// ActiveSlotsCoefficient is the expected fraction of slots filled with a block
+	ActiveSlotsCoefficient = 0.75
+)
+
+// ExpectedEpochBlocks is the expected number of blocks produced in an epoch
+var ExpectedEpochBlocks = int(SlotsPerEpoch * ActiveSlotsCoefficient)
+
+// UnlockEvent contains a single vesting event of a timed account
+type UnlockEvent struct {
+	Slot               uint64       `json:"slot"`
+	Date               time.Time    `json:"date"`
+	AmountUnlocked     types.Amount `json:"amount_unlocked"`
+	CumulativeUnlocked types.Amount `json:"cumulative_unlocked"`
+}
+
+// UnlockSchedule returns up to limit upcoming vesting events for the ledger entry.
+// Event dates are projected from the given reference slot and time.
+func UnlockSchedule(entry LedgerEntry, refSlot uint64, refTime time.Time, limit int) []UnlockEvent {
+	result := []UnlockEvent{}
+
+	initial := entry.TimingInitialMinimumBalance
+	if initial.Int == nil || initial.Sign() <= 0 || entry.TimingCliffTime == nil {
+		return result
+	}
+
+	cliffTime := uint64(*entry.TimingCliffTime)
+	cliffAmount := big.NewInt(0)
+	if entry.TimingCliffAmount.Int != nil {
+		cliffAmount.Set(entry.TimingCliffAmount.Int)
+	}
+
+	var period uint64
+	if entry.TimingVestingPeriod != nil && *entry.TimingVestingPeriod > 0 {
+		period = uint64(*entry.TimingVestingPeriod)
+	}
+
+	increment := big.NewInt(0)
+	if entry.TimingVestingIncrement != nil && *entry.TimingVestingIncrement > 0 {
+		increment.SetInt64(int64(*entry.TimingVestingIncrement))
+	}
+
+	unlocked := new(big.Int)
+
+	addEvent := func(slot uint64, amount *big.Int) {
+		if amount.Cmp(new(big.Int).Sub(initial.Int, unlocked)) > 0 {
+			amount = new(big.Int).Sub(initial.Int, unlocked)
+		}
+		unlocked = new(big.Int).Add(unlocked, amount)
+
+		if slot < refSlot || amount.Sign() == 0 {
+			return
+		}
+
+		result = append(result, UnlockEvent{
+			Slot:               slot,
+			Date:               refTime.Add(time.Duration(slot-refSlot) * SlotDuration),
+			AmountUnlocked:     types.Amount{Int: amount},
+			CumulativeUnlocked: types.Amount{Int: new(big.Int).Set(unlocked)},
+		})
+	}
+
+	addEvent(cliffTime, cliffAmount)
+
+	if period == 0 || increment.Sign() == 0 {
+		return result
+	}
+
+	// Skip the vesting periods that have already passed
+	step := uint64(1)
+	if refSlot > cliffTime {
+		step = (refSlot - cliffTime + period - 1) / period
+		if step > 1 {
+			passed := new(big.Int).Mul(increment, new(big.Int).SetUint64(step-1))
+			addEvent(cliffTime+(step-1)*period, passed)
+		}
+	}
+
+	for ; len(result) < limit && unlocked.Cmp(initial.Int) < 0; step++ {
+		addEvent(cliffTime+step*period, increment)
+	}
+
+	return result
+}
@@ -0,0 +1,128 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/figment-networks/mina-indexer/model/types"
+)
+
+func timedEntry(initial, cliffTime, cliffAmount, period, increment int) LedgerEntry {
+	return LedgerEntry{
+		TimingInitialMinimumBalance: types.NewInt64Amount(int64(initial)),
+		TimingCliffTime:             &cliffTime,
+		TimingCliffAmount:           types.NewInt64Amount(int64(cliffAmount)),
+		TimingVestingPeriod:         &period,
+		TimingVestingIncrement:      &increment,
+	}
+}
+
+func TestUnlockSchedule(t *testing.T) {
+	type event struct {
+		slot       uint64
+		amount     string
+		cumulative string
+	}
+
+	examples := []struct {
+		name     string
+		entry    LedgerEntry
+		refSlot  uint64
+		limit    int
+		expected []event
+	}{
+		{
+			name:     "untimed account",
+			entry:    LedgerEntry{},
+			limit:    100,
+			expected: []event{},
+		},
+		{
+			name:     "cliff only",
+			entry:    timedEntry(1000, 100, 1000, 0, 0),
+			limit:    100,
+			expected: []event{{100, "1000", "1000"}},
+		},
+		{
+			name:  "cliff and increments",
+			entry: timedEntry(1000, 100, 400, 10, 200),
+			limit: 100,
+			expected: []event{
+				{100, "400", "400"},
+				{110, "200", "600"},
+				{120, "200", "800"},
+				{130, "200", "1000"},
+			},
+		},
+		{
+			name:  "last increment is capped by the initial balance",
+			entry: timedEntry(1000, 100, 400, 10, 250),
+			limit: 100,
+			expected: []event{
+				{100, "400", "400"},
+				{110, "250", "650"},
+				{120, "250", "900"},
+				{130, "100", "1000"},
+			},
+		},
+		{
+			name:    "passed events are skipped",
+			entry:   timedEntry(1000, 100, 400, 10, 200),
+			refSlot: 125,
+			limit:   100,
+			expected: []event{
+				{130, "200", "1000"},
+			},
+		},
+		{
+			name:    "event at the reference slot is included",
+			entry:   timedEntry(1000, 100, 400, 10, 200),
+			refSlot: 120,
+			limit:   100,
+			expected: []event{
+				{120, "200", "800"},
+				{130, "200", "1000"},
+			},
+		},
+		{
+			name:     "fully vested account",
+			entry:    timedEntry(1000, 100, 400, 10, 200),
+			refSlot:  200,
+			limit:    100,
+			expected: []event{},
+		},
+	}
+
+	for _, example := range examples {
+		t.Run(example.name, func(t *testing.T) {
+			result := UnlockSchedule(example.entry, example.refSlot, time.Now(), example.limit)
+
+			events := []event{}
+			for _, e := range result {
+				events = append(events, event{e.Slot, e.AmountUnlocked.String(), e.CumulativeUnlocked.String()})
+			}
+			assert.Equal(t, example.expected, events)
+		})
+	}
+}
+
+func TestUnlockScheduleLimit(t *testing.T) {
+	entry := timedEntry(1000000, 100, 1, 1, 1)
+
+	result := UnlockSchedule(entry, 0, time.Now(), 100)
+	assert.Len(t, result, 100)
+	assert.Equal(t, uint64(199), result[99].Slot)
+	assert.Equal(t, "100", result[99].CumulativeUnlocked.String())
+}
+
+func TestUnlockScheduleDates(t *testing.T) {
+	refTime := time.Date(2021, 3, 17, 0, 0, 0, 0, time.UTC)
+	entry := timedEntry(1000, 110, 400, 20, 600)
+
+	result := UnlockSchedule(entry, 100, refTime, 100)
+	assert.Len(t, result, 2)
+	assert.Equal(t, refTime.Add(30*time.Minute), result[0].Date)
+	assert.Equal(t, refTime.Add(90*time.Minute), result[1].Date)
+}
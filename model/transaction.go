@@ -1,7 +1,10 @@
 package model
 
 import (
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/figment-networks/mina-indexer/model/types"
@@ -15,10 +18,12 @@ const (
 	TxTypeCoinbaseFeeTransfer = "fee_transfer_via_coinbase"
 	TxTypeFeeTransfer         = "fee_transfer"
 	TxTypeSnarkFee            = "snark_fee"
+	TxTypeZkApp               = "zkapp"
 
 	// Transaction statuses
 	TxStatusApplied = "applied"
 	TxStatusFailed  = "failed"
+	TxStatusPending = "pending"
 )
 
 var (
@@ -29,6 +34,7 @@ var (
 		TxTypeCoinbaseFeeTransfer,
 		TxTypeFeeTransfer,
 		TxTypeSnarkFee,
+		TxTypeZkApp,
 	}
 )
 
@@ -40,21 +46,57 @@ type Transaction struct {
 	BlockHash               string       `json:"block_hash"`
 	BlockHeight             uint64       `json:"block_height"`
 	Time                    time.Time    `json:"time"`
+	Timestamp               *time.Time   `json:"timestamp"`
 	Sender                  *string      `json:"sender"`
 	Receiver                string       `json:"receiver"`
 	Amount                  types.Amount `json:"amount"`
 	Fee                     types.Amount `json:"fee"`
 	Nonce                   *int         `json:"nonce"`
 	Memo                    *string      `json:"memo"`
+	MemoHash                *string      `json:"memo_hash"`
 	Status                  string       `json:"status"`
 	Canonical               bool         `json:"canonical"`
 	FailureReason           *string      `json:"failure_reason"`
 	SequenceNumber          *int         `json:"sequence_number"`
 	SecondarySequenceNumber *int         `json:"secondary_sequence_number"`
+	ZkAppBody               *ZkAppBody   `json:"zkapp_body,omitempty" gorm:"column:zkapp_body"`
 	CreatedAt               time.Time    `json:"-"`
 	UpdatedAt               time.Time    `json:"-"`
 }
 
+// ZkAppBody contains the zkApp command details
+type ZkAppBody struct {
+	AccountUpdates []AccountUpdate `json:"account_updates"`
+}
+
+// AccountUpdate contains a single account update of the zkApp command
+type AccountUpdate struct {
+	PublicKey      string `json:"public_key"`
+	TokenID        string `json:"token_id"`
+	BalanceChange  string `json:"balance_change"`
+	IncrementNonce bool   `json:"increment_nonce"`
+	CallDepth      int    `json:"call_depth"`
+}
+
+// Value returns a serialized value
+func (b ZkAppBody) Value() (driver.Value, error) {
+	return json.Marshal(b)
+}
+
+// Scan assigns the value from interface
+func (b *ZkAppBody) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, b)
+	case string:
+		return json.Unmarshal([]byte(v), b)
+	default:
+		return fmt.Errorf("invalid zkapp body: %v", value)
+	}
+}
+
 // TableName returns the model table name
 func (Transaction) TableName() string {
 	return "transactions"
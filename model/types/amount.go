@@ -67,6 +67,11 @@ func (a Amount) String() string {
 	return a.Int.String()
 }
 
+// IsNil returns true if amount has no value
+func (a Amount) IsNil() bool {
+	return a.Int == nil
+}
+
 // Compare compares two amounts
 func (a Amount) Compare(b Amount) int {
 	return a.Cmp(b.Int)
@@ -0,0 +1,4 @@
+package types
+
+// Percentage represents a share in percents, e.g. 12.5 for 12.5%
+type Percentage float64
@@ -0,0 +1,44 @@
+package util
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/figment-networks/mina-indexer/model/types"
+)
+
+// nanoMINADigits is the number of decimal places of a MINA amount
+const nanoMINADigits = 9
+
+// NanoMINAToMINA returns a nanomina amount formatted in MINA without trailing zeros
+func NanoMINAToMINA(n *big.Int) string {
+	if n == nil {
+		return ""
+	}
+
+	digits := new(big.Int).Abs(n).String()
+	if len(digits) <= nanoMINADigits {
+		digits = strings.Repeat("0", nanoMINADigits-len(digits)+1) + digits
+	}
+
+	whole := digits[:len(digits)-nanoMINADigits]
+	fraction := strings.TrimRight(digits[len(digits)-nanoMINADigits:], "0")
+
+	result := whole
+	if fraction != "" {
+		result += "." + fraction
+	}
+	if n.Sign() < 0 {
+		result = "-" + result
+	}
+
+	return result
+}
+
+// VotingPower returns the share of the total stake controlled by the delegated stake
+func VotingPower(delegatedStake, totalStake types.Amount) types.Percentage {
+	if delegatedStake.IsNil() || totalStake.IsNil() {
+		return 0
+	}
+	return types.Percentage(delegatedStake.PercentOf(totalStake))
+}
@@ -0,0 +1,36 @@
+package util
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/figment-networks/mina-indexer/model/types"
+)
+
+func TestNanoMINAToMINA(t *testing.T) {
+	examples := []struct {
+		input    *big.Int
+		expected string
+	}{
+		{big.NewInt(0), "0"},
+		{big.NewInt(1), "0.000000001"},
+		{big.NewInt(1000000000), "1"},
+		{big.NewInt(1500000000), "1.5"},
+		{big.NewInt(-2500000), "-0.0025"},
+		{new(big.Int).SetUint64(math.MaxUint64), "18446744073.709551615"},
+	}
+
+	for _, example := range examples {
+		assert.Equal(t, example.expected, NanoMINAToMINA(example.input))
+	}
+	assert.Equal(t, "", NanoMINAToMINA(nil))
+}
+
+func TestVotingPower(t *testing.T) {
+	assert.Equal(t, types.Percentage(25), VotingPower(types.NewInt64Amount(250), types.NewInt64Amount(1000)))
+	assert.Equal(t, types.Percentage(0), VotingPower(types.NewInt64Amount(250), types.NewInt64Amount(0)))
+	assert.Equal(t, types.Percentage(0), VotingPower(types.Amount{}, types.NewInt64Amount(1000)))
+}
@@ -0,0 +1,23 @@
+package util
+
+import "math"
+
+// poissonZ90 is the z-score of a two-sided 90% confidence interval
+const poissonZ90 = 1.645
+
+// ExpectedBlocks returns the expected number of blocks produced with the given stake
+// weight in an epoch, along with a 90% confidence interval using the normal
+// approximation of the Poisson distribution.
+func ExpectedBlocks(weight float64, epochBlocks int) (expected, low, high float64) {
+	if weight <= 0 || epochBlocks <= 0 {
+		return 0, 0, 0
+	}
+
+	expected = weight * float64(epochBlocks)
+	spread := poissonZ90 * math.Sqrt(expected)
+
+	low = math.Max(0, expected-spread)
+	high = expected + spread
+
+	return expected, low, high
+}
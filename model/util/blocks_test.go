@@ -0,0 +1,24 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpectedBlocks(t *testing.T) {
+	expected, low, high := ExpectedBlocks(0, 5355)
+	assert.Equal(t, 0.0, expected)
+	assert.Equal(t, 0.0, low)
+	assert.Equal(t, 0.0, high)
+
+	expected, low, high = ExpectedBlocks(0.01, 5355)
+	assert.InDelta(t, 53.55, expected, 0.001)
+	assert.InDelta(t, 41.51, low, 0.01)
+	assert.InDelta(t, 65.59, high, 0.01)
+
+	expected, low, high = ExpectedBlocks(0.0001, 5355)
+	assert.InDelta(t, 0.5355, expected, 0.001)
+	assert.Equal(t, 0.0, low)
+	assert.InDelta(t, 1.739, high, 0.01)
+}
@@ -2,6 +2,7 @@ package util
 
 import (
 	"crypto/sha1"
+	"crypto/sha256"
 	"fmt"
 )
 
@@ -11,3 +12,8 @@ func SHA1(input string) string {
 	h.Write([]byte(input))
 	return fmt.Sprintf("%x", h.Sum(nil))
 }
+
+// SHA256 returns a SHA256 digest of a given string in hex format
+func SHA256(input string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(input)))
+}
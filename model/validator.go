@@ -15,6 +15,7 @@ type Validator struct {
 	BlocksCreated  int          `json:"blocks_created"`
 	BlocksProposed int          `json:"blocks_proposed"`
 	Stake          types.Amount `json:"stake"`
+	StakeRank      *int         `json:"stake_rank"`
 	Delegations    int          `json:"delegations"`
 	StartHeight    uint64       `json:"start_height"`
 	StartTime      time.Time    `json:"start_time"`
@@ -24,6 +25,12 @@ type Validator struct {
 	UpdatedAt      time.Time    `json:"-"`
 }
 
+// EpochValidator contains a validator with the blocks produced in an epoch
+type EpochValidator struct {
+	Validator
+	BlocksProduced int `json:"blocks_produced"`
+}
+
 type ValidatorStat struct {
 	Time                string `json:"time"`
 	Bucket              string `json:"bucket"`
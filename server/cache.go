@@ -0,0 +1,134 @@
+package server
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/figment-networks/mina-indexer/model"
+)
+
+const (
+	cacheMaxItems      = 10000
+	cacheSweepInterval = time.Minute
+)
+
+// memoryCache is a simple in-memory cache with per-item expiration.
+// Expired items are swept on Set and the cache holds at most maxItems items.
+type memoryCache struct {
+	items     map[string]cacheItem
+	maxItems  int
+	lastSweep time.Time
+	lock      sync.RWMutex
+}
+
+type cacheItem struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// mempoolCacheEntry holds the cached node transaction pool
+type mempoolCacheEntry struct {
+	transactions []model.Transaction
+	fetchedAt    time.Time
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{
+		items:     map[string]cacheItem{},
+		maxItems:  cacheMaxItems,
+		lastSweep: time.Now(),
+	}
+}
+
+// Get returns a non-expired value for the key
+func (c *memoryCache) Get(key string) (interface{}, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	item, ok := c.items[key]
+	if !ok || time.Now().After(item.expiresAt) {
+		return nil, false
+	}
+	return item.value, true
+}
+
+// Set stores the value for the given duration
+func (c *memoryCache) Set(key string, value interface{}, ttl time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := time.Now()
+	if now.Sub(c.lastSweep) >= cacheSweepInterval {
+		c.sweep(now)
+	}
+	if _, ok := c.items[key]; !ok && len(c.items) >= c.maxItems {
+		c.sweep(now)
+		if len(c.items) >= c.maxItems {
+			c.evictOldest()
+		}
+	}
+
+	c.items[key] = cacheItem{
+		value:     value,
+		expiresAt: now.Add(ttl),
+	}
+}
+
+// Len returns the number of stored items, including the expired ones not swept yet
+func (c *memoryCache) Len() int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return len(c.items)
+}
+
+// sweep removes the expired items
+func (c *memoryCache) sweep(now time.Time) {
+	for k, item := range c.items {
+		if now.After(item.expiresAt) {
+			delete(c.items, k)
+		}
+	}
+	c.lastSweep = now
+}
+
+// evictOldest removes the item closest to expiration
+func (c *memoryCache) evictOldest() {
+	var (
+		oldestKey string
+		oldestAt  time.Time
+	)
+	for k, item := range c.items {
+		if oldestKey == "" || item.expiresAt.Before(oldestAt) {
+			oldestKey = k
+			oldestAt = item.expiresAt
+		}
+	}
+	delete(c.items, oldestKey)
+}
+
+// Delete removes the key from the cache and returns the number of removed items.
+// A key ending with * removes all items with the key prefix.
+func (c *memoryCache) Delete(key string) int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if !strings.HasSuffix(key, "*") {
+		if _, ok := c.items[key]; !ok {
+			return 0
+		}
+		delete(c.items, key)
+		return 1
+	}
+
+	prefix := strings.TrimSuffix(key, "*")
+	count := 0
+	for k := range c.items {
+		if strings.HasPrefix(k, prefix) {
+			delete(c.items, k)
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,42 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryCacheSweepsExpiredItems(t *testing.T) {
+	cache := newMemoryCache()
+	cache.Set("expired", 1, -time.Second)
+	cache.lastSweep = time.Now().Add(-cacheSweepInterval)
+
+	cache.Set("fresh", 2, time.Minute)
+
+	_, ok := cache.Get("expired")
+	assert.False(t, ok)
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestMemoryCacheMaxItems(t *testing.T) {
+	cache := newMemoryCache()
+	cache.maxItems = 2
+
+	cache.Set("a", 1, time.Minute)
+	cache.Set("b", 2, time.Hour)
+	cache.Set("c", 3, time.Hour)
+
+	assert.Equal(t, 2, cache.Len())
+
+	_, ok := cache.Get("a")
+	assert.False(t, ok)
+
+	val, ok := cache.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, 3, val)
+
+	// Replacing an existing key does not evict other items
+	cache.Set("b", 4, time.Hour)
+	assert.Equal(t, 2, cache.Len())
+}
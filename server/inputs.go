@@ -2,18 +2,321 @@ package server
 
 import (
 	"errors"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/figment-networks/mina-indexer/client/graph"
+	"github.com/figment-networks/mina-indexer/store"
 )
 
 type blockTimesParams struct {
 	Limit int64 `form:"limit"`
 }
 
+type blockTimesSeriesParams struct {
+	Window string `form:"window"`
+	Bucket string `form:"bucket"`
+}
+
+func (p *blockTimesSeriesParams) validate() error {
+	switch p.Window {
+	case "":
+		p.Window = "30d"
+	case "7d", "30d", "90d":
+	default:
+		return errors.New("window must be one of: 7d, 30d, 90d")
+	}
+
+	switch p.Bucket {
+	case "":
+		p.Bucket = "day"
+	case "hour", "day":
+	default:
+		return errors.New("bucket must be one of: hour, day")
+	}
+
+	return nil
+}
+
 type accountsIndexParams struct {
 	Height int64 `form:"height"`
 }
 
+type newAccountsParams struct {
+	Since  time.Time `form:"since" time_format:"2006-01-02" binding:"required"`
+	Limit  int       `form:"limit"`
+	Offset int       `form:"offset"`
+}
+
+func (p *newAccountsParams) validate() error {
+	if p.Limit <= 0 {
+		p.Limit = 100
+	}
+	if p.Limit > 1000 {
+		return errors.New("max limit is 1000")
+	}
+	if p.Offset < 0 {
+		return errors.New("offset must be positive")
+	}
+	return nil
+}
+
+type blocksFullSearchParams struct {
+	Query string `form:"q" binding:"required"`
+	Limit int    `form:"limit"`
+}
+
+func (p *blocksFullSearchParams) validate() error {
+	if len(p.Query) < 3 {
+		return errors.New("query must be at least 3 characters")
+	}
+	if p.Limit <= 0 {
+		p.Limit = 25
+	}
+	if p.Limit > 100 {
+		return errors.New("max limit is 100")
+	}
+	return nil
+}
+
+type epochValidatorsParams struct {
+	Sort  string `form:"sort"`
+	Limit int    `form:"limit"`
+}
+
+func (p *epochValidatorsParams) validate() error {
+	if p.Sort == "" {
+		p.Sort = "blocks_produced"
+	}
+	if p.Sort != "blocks_produced" {
+		return errors.New("sort must be blocks_produced")
+	}
+	if p.Limit <= 0 {
+		p.Limit = 25
+	}
+	if p.Limit > 100 {
+		return errors.New("max limit is 100")
+	}
+	return nil
+}
+
+type epochBlocksParams struct {
+	Limit  int `form:"limit"`
+	Offset int `form:"offset"`
+}
+
+func (p *epochBlocksParams) validate() error {
+	if p.Limit <= 0 {
+		p.Limit = 25
+	}
+	if p.Limit > 100 {
+		return errors.New("max limit is 100")
+	}
+	if p.Offset < 0 {
+		return errors.New("offset must be positive")
+	}
+	return nil
+}
+
+type memoTransactionsParams struct {
+	Limit  int `form:"limit"`
+	Offset int `form:"offset"`
+}
+
+func (p *memoTransactionsParams) validate() error {
+	if p.Limit <= 0 {
+		p.Limit = 100
+	}
+	if p.Limit > 1000 {
+		return errors.New("max limit is 1000")
+	}
+	if p.Offset < 0 {
+		return errors.New("offset must be positive")
+	}
+	return nil
+}
+
+type stakingHistoryParams struct {
+	Limit int `form:"limit"`
+	After int `form:"after"`
+}
+
+func (p *stakingHistoryParams) validate() error {
+	if p.Limit <= 0 {
+		p.Limit = 100
+	}
+	if p.Limit > 1000 {
+		return errors.New("max limit is 1000")
+	}
+	if p.After < 0 {
+		return errors.New("after must be positive")
+	}
+	return nil
+}
+
+type transactionParams struct {
+	Enrich bool `form:"enrich"`
+}
+
+type delegationHistoryParams struct {
+	Epoch *int `form:"epoch"`
+}
+
+func (p *delegationHistoryParams) validate() error {
+	if p.Epoch != nil && *p.Epoch < 0 {
+		return errors.New("epoch must be positive")
+	}
+	return nil
+}
+
+type delegatorsHistoryParams struct {
+	Epoch *int `form:"epoch"`
+	Limit int  `form:"limit"`
+}
+
+func (p *delegatorsHistoryParams) validate() error {
+	if p.Epoch != nil && *p.Epoch < 0 {
+		return errors.New("epoch must be positive")
+	}
+	if p.Limit <= 0 {
+		p.Limit = 10
+	}
+	if p.Limit > 20 {
+		return errors.New("max limit is 20")
+	}
+	return nil
+}
+
+type transactionVolumeParams struct {
+	Days int `form:"days"`
+}
+
+func (p *transactionVolumeParams) validate() error {
+	if p.Days <= 0 {
+		p.Days = 30
+	}
+	if p.Days > 365 {
+		return errors.New("days must not be greater than 365")
+	}
+	return nil
+}
+
+type sendTransactionParams struct {
+	From       string  `json:"from" binding:"required"`
+	To         string  `json:"to" binding:"required"`
+	Amount     string  `json:"amount" binding:"required"`
+	Fee        string  `json:"fee" binding:"required"`
+	Nonce      string  `json:"nonce" binding:"required"`
+	Memo       *string `json:"memo"`
+	ValidUntil *string `json:"valid_until"`
+	Signature  struct {
+		Field  string `json:"field" binding:"required"`
+		Scalar string `json:"scalar" binding:"required"`
+	} `json:"signature" binding:"required"`
+}
+
+func (p *sendTransactionParams) validate() error {
+	errs := store.ValidationErrors{}
+
+	if !rePublicKey.MatchString(p.From) {
+		errs = append(errs, "sender is not a valid public key")
+	}
+	if !rePublicKey.MatchString(p.To) {
+		errs = append(errs, "receiver is not a valid public key")
+	}
+	if amount, err := strconv.ParseUint(p.Amount, 10, 64); err != nil || amount == 0 {
+		errs = append(errs, "amount must be greater than 0")
+	}
+	if _, err := strconv.ParseUint(p.Fee, 10, 64); err != nil {
+		errs = append(errs, "fee must be a positive number")
+	}
+	if _, err := strconv.ParseUint(p.Nonce, 10, 32); err != nil {
+		errs = append(errs, "nonce must be a positive number")
+	}
+	if p.ValidUntil != nil {
+		if _, err := strconv.ParseUint(*p.ValidUntil, 10, 32); err != nil {
+			errs = append(errs, "valid until must be a slot number")
+		}
+	}
+	if p.Memo != nil && len(*p.Memo) > 32 {
+		errs = append(errs, "memo must not be longer than 32 bytes")
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (p sendTransactionParams) paymentInput() graph.SendPaymentInput {
+	return graph.SendPaymentInput{
+		From:       p.From,
+		To:         p.To,
+		Amount:     p.Amount,
+		Fee:        p.Fee,
+		Nonce:      &p.Nonce,
+		Memo:       p.Memo,
+		ValidUntil: p.ValidUntil,
+	}
+}
+
+func (p sendTransactionParams) signature() graph.SignatureInput {
+	return graph.SignatureInput{
+		Field:  p.Signature.Field,
+		Scalar: p.Signature.Scalar,
+	}
+}
+
+type validatorStatsParams struct {
+	Days   uint   `form:"days"`
+	Bucket string `form:"bucket"`
+}
+
+func (p *validatorStatsParams) validate() error {
+	if p.Days == 0 {
+		p.Days = 30
+	}
+	if p.Days > 365 {
+		return errors.New("days must not be greater than 365")
+	}
+
+	switch p.Bucket {
+	case "":
+		p.Bucket = "day"
+	case "hour", "day", "week":
+	default:
+		return errors.New("bucket must be one of: hour, day, week")
+	}
+
+	return nil
+}
+
+// period returns the number of stat buckets covering the requested days
+func (p *validatorStatsParams) period() uint {
+	switch p.Bucket {
+	case "hour":
+		return p.Days * 24
+	case "week":
+		return (p.Days + 6) / 7
+	default:
+		return p.Days
+	}
+}
+
+// interval returns the store time bucket
+func (p *validatorStatsParams) interval() string {
+	switch p.Bucket {
+	case "hour":
+		return store.BucketHour
+	case "week":
+		return store.BucketWeek
+	default:
+		return store.BucketDay
+	}
+}
+
 func (p *blockTimesParams) setDefaults() {
 	if p.Limit <= 1 {
 		p.Limit = 100
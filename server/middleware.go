@@ -1,7 +1,13 @@
 package server
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -10,12 +16,163 @@ import (
 	"github.com/figment-networks/mina-indexer/config"
 )
 
-// corsMiddleware inject CORS headers into the response
-func corsMiddleware() gin.HandlerFunc {
+// corsMiddleware inject CORS headers into the response for allowed origins
+func corsMiddleware(allowedOrigins []string) gin.HandlerFunc {
+	allowAll := false
+	origins := map[string]bool{}
+	for _, origin := range allowedOrigins {
+		if origin == "*" {
+			allowAll = true
+		}
+		origins[strings.TrimSuffix(origin, "/")] = true
+	}
+
 	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			return
+		}
+
+		if allowAll {
+			c.Header("Access-Control-Allow-Origin", "*")
+		} else if origins[origin] {
+			c.Header("Access-Control-Allow-Origin", origin)
+			c.Header("Vary", "Origin")
+		} else {
+			return
+		}
+
 		c.Header("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, X-Request-ID")
 		c.Header("Access-Control-Expose-Headers", "*")
-		c.Header("Access-Control-Allow-Origin", "*")
+
+		if c.Request.Method == http.MethodOptions {
+			c.AbortWithStatus(http.StatusNoContent)
+		}
+	}
+}
+
+// securityHeadersMiddleware sets the browser security headers.
+// The API only serves JSON, so the content security policy does not allow loading anything.
+func securityHeadersMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("X-Content-Type-Options", "nosniff")
+		c.Header("X-Frame-Options", "DENY")
+		c.Header("X-XSS-Protection", "1; mode=block")
+		c.Header("Referrer-Policy", "no-referrer")
+		c.Header("Content-Security-Policy", "default-src 'none'; frame-ancestors 'none'")
+	}
+}
+
+// bodySizeMiddleware limits the size of request bodies.
+// Only request bodies are limited, streamed responses are not affected.
+func bodySizeMiddleware(maxBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > maxBytes {
+			jsonError(c, http.StatusRequestEntityTooLarge, errBodyTooLarge)
+			return
+		}
+		if c.Request.Body != nil {
+			c.Request.Body = &limitedBody{ReadCloser: c.Request.Body, remaining: maxBytes}
+		}
+	}
+}
+
+// limitedBody fails reads past the size limit with errBodyTooLarge
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	err       error
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+
+	// Read one byte past the limit to tell a body of exactly maxBytes from a larger one
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+
+	n, err := b.ReadCloser.Read(p)
+	if int64(n) <= b.remaining {
+		b.remaining -= int64(n)
+		return n, err
+	}
+
+	n = int(b.remaining)
+	b.remaining = 0
+	b.err = errBodyTooLarge
+	return n, b.err
+}
+
+// responseSizeMiddleware rejects responses larger than maxBytes with HTTP 413.
+// Streamed responses that exceed the limit after the headers were sent are truncated.
+func responseSizeMiddleware(maxBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer = &limitedResponseWriter{
+			ResponseWriter: c.Writer,
+			ctx:            c,
+			maxBytes:       maxBytes,
+		}
+	}
+}
+
+// limitedResponseWriter discards the response body once it exceeds maxBytes
+type limitedResponseWriter struct {
+	gin.ResponseWriter
+
+	ctx      *gin.Context
+	maxBytes int64
+	size     int64
+	exceeded bool
+}
+
+func (w *limitedResponseWriter) Write(data []byte) (int, error) {
+	if w.exceeded {
+		return len(data), nil
+	}
+
+	if w.size+int64(len(data)) > w.maxBytes {
+		w.exceeded = true
+		w.ctx.Error(errRespTooLarge)
+
+		if !w.ResponseWriter.Written() {
+			body, _ := json.Marshal(newErrorResponse(http.StatusRequestEntityTooLarge, errRespTooLarge))
+
+			header := w.Header()
+			header.Del("ETag")
+			header.Del("Content-Length")
+			header.Set("Content-Type", "application/json; charset=utf-8")
+
+			w.ResponseWriter.WriteHeader(http.StatusRequestEntityTooLarge)
+			w.ResponseWriter.Write(body)
+		}
+
+		// Report the data as written, gin panics on render errors
+		return len(data), nil
+	}
+
+	n, err := w.ResponseWriter.Write(data)
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *limitedResponseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// adminAuthMiddleware only allows requests with the admin bearer token
+func adminAuthMiddleware(token string) gin.HandlerFunc {
+	expected := []byte("Bearer " + token)
+
+	return func(c *gin.Context) {
+		given := []byte(c.GetHeader("Authorization"))
+		if subtle.ConstantTimeCompare(given, expected) != 1 {
+			jsonError(c, http.StatusUnauthorized, errUnauthorized)
+			return
+		}
 	}
 }
 
@@ -47,9 +204,17 @@ func timeBucketMiddleware() gin.HandlerFunc {
 	}
 }
 
+// requestLoggerMiddleware logs every request with a unique request ID
 func requestLoggerMiddleware(logger *logrus.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
+
+		requestID := c.GetHeader("x-request-id")
+		if requestID == "" {
+			requestID = newRequestID()
+		}
+		c.Header("X-Request-ID", requestID)
+
 		c.Next()
 
 		status := c.Writer.Status()
@@ -57,13 +222,13 @@ func requestLoggerMiddleware(logger *logrus.Logger) gin.HandlerFunc {
 		msg := ""
 
 		field := logger.WithFields(logrus.Fields{
-			"id":       c.GetHeader("x-request-id"),
-			"method":   c.Request.Method,
-			"client":   c.ClientIP(),
-			"status":   status,
-			"duration": duration.Milliseconds(),
-			"path":     c.Request.URL.Path,
-			"params":   c.Request.URL.Query(),
+			"request_id": requestID,
+			"method":     c.Request.Method,
+			"client":     c.ClientIP(),
+			"status":     status,
+			"duration":   duration.Milliseconds(),
+			"path":       c.Request.URL.Path,
+			"params":     c.Request.URL.Query(),
 		})
 
 		if err := c.Errors.Last(); err != nil {
@@ -80,3 +245,15 @@ func requestLoggerMiddleware(logger *logrus.Logger) gin.HandlerFunc {
 		}
 	}
 }
+
+// newRequestID returns a random UUID v4 string
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseSizeMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(responseSizeMiddleware(64))
+	router.GET("/small", func(c *gin.Context) {
+		jsonOk(c, gin.H{"hash": "abc"})
+	})
+	router.GET("/large", func(c *gin.Context) {
+		jsonOkWithETag(c, gin.H{"hash": strings.Repeat("a", 100)})
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/small", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, `{"hash":"abc"}`, resp.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/large", nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.Code)
+	assert.Empty(t, resp.Header().Get("ETag"))
+	assert.Contains(t, resp.Body.String(), errRespTooLarge.Error())
+}
+
+func TestSecurityHeadersMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(securityHeadersMiddleware())
+	router.GET("/height", func(c *gin.Context) {
+		jsonOk(c, gin.H{"height": 1})
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/height", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, "nosniff", resp.Header().Get("X-Content-Type-Options"))
+	assert.Equal(t, "DENY", resp.Header().Get("X-Frame-Options"))
+	assert.Equal(t, "no-referrer", resp.Header().Get("Referrer-Policy"))
+	assert.Equal(t, "default-src 'none'; frame-ancestors 'none'", resp.Header().Get("Content-Security-Policy"))
+}
+
+func TestBodySizeMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(bodySizeMiddleware(16))
+	router.POST("/echo", func(c *gin.Context) {
+		var body map[string]string
+		if err := c.ShouldBindJSON(&body); err != nil {
+			badRequest(c, err)
+			return
+		}
+		jsonOk(c, body)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(`{"hash":"abcde"}`))
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	// Chunked requests have no content length and fail while reading the body
+	req = httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(`{"hash":"abcdef"}`))
+	req.ContentLength = -1
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.Code)
+	assert.Contains(t, resp.Body.String(), errBodyTooLarge.Error())
+}
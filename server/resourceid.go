@@ -15,7 +15,9 @@ const (
 )
 
 var (
-	reNumeric = regexp.MustCompile(`^[0-9]+$`)
+	reNumeric   = regexp.MustCompile(`^[0-9]+$`)
+	reMemoHash  = regexp.MustCompile(`^[0-9a-f]{64}$`)
+	rePublicKey = regexp.MustCompile(`^B62[1-9A-HJ-NP-Za-km-z]{52}$`)
 )
 
 type rid struct {
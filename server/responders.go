@@ -1,32 +1,66 @@
 package server
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/figment-networks/mina-indexer/client/graph"
 	"github.com/figment-networks/mina-indexer/store"
 )
 
-// jsonError renders an error response
+var (
+	errBodyTooLarge    = errors.New("request body too large")
+	errRespTooLarge    = errors.New("response body too large, try a smaller limit")
+	errUnauthorized    = errors.New("unauthorized")
+	errNoArchive       = errors.New("archive endpoint is not configured")
+	errInvalidMemoHash = errors.New("memo hash must be a hex encoded SHA256 digest")
+)
+
+// jsonError renders an error response.
+// Each message of validation and GraphQL errors is listed in the response details.
 func jsonError(c *gin.Context, status int, err interface{}) {
-	var message interface{}
+	c.AbortWithStatusJSON(status, newErrorResponse(status, err))
+}
+
+func newErrorResponse(status int, err interface{}) ErrorResponse {
+	resp := ErrorResponse{Status: status}
 
 	switch v := err.(type) {
 	case error:
-		message = v.Error()
+		resp.Error = v.Error()
+
+		var validationErr store.ValidationErrors
+		if errors.As(v, &validationErr) {
+			resp.Details = validationErr
+		}
+
+		var graphErr graph.ResponseError
+		if errors.As(v, &graphErr) {
+			for _, e := range graphErr.Errors {
+				resp.Details = append(resp.Details, e.Message)
+			}
+		}
 	default:
-		message = v
+		resp.Error = fmt.Sprint(v)
 	}
 
-	c.AbortWithStatusJSON(status, gin.H{
-		"status": status,
-		"error":  message,
-	})
+	return resp
 }
 
 // badRequest renders a HTTP 400 bad request response
 func badRequest(c *gin.Context, err interface{}) {
+	// Reading a body over the limit set by bodySizeMiddleware fails with errBodyTooLarge
+	if e, ok := err.(error); ok && errors.Is(e, errBodyTooLarge) {
+		jsonError(c, http.StatusRequestEntityTooLarge, errBodyTooLarge)
+		return
+	}
 	jsonError(c, http.StatusBadRequest, err)
 }
 
@@ -55,6 +89,27 @@ func jsonOk(c *gin.Context, data interface{}) {
 	}
 }
 
+// jsonOkWithETag renders a successful response tagged with the digest of its body.
+// An empty 304 response is rendered when the client already has the same body.
+func jsonOkWithETag(c *gin.Context, data interface{}) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		serverError(c, err)
+		return
+	}
+
+	digest := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(digest[:8]) + `"`
+
+	c.Header("ETag", etag)
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
 // shouldReturn is a shorthand method for handling resource errors
 func shouldReturn(c *gin.Context, err error) bool {
 	if err == nil {
@@ -70,3 +125,9 @@ func shouldReturn(c *gin.Context, err error) bool {
 
 	return true
 }
+
+// setTotalCount sets the total number of records matching a list request
+func setTotalCount(c *gin.Context, count int64) {
+	c.Header("X-Total-Count", strconv.FormatInt(count, 10))
+	c.Header("Access-Control-Expose-Headers", "X-Total-Count")
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/figment-networks/mina-indexer/client/graph"
+	"github.com/figment-networks/mina-indexer/store"
+)
+
+func TestJSONOkWithETag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/blocks/:id", func(c *gin.Context) {
+		jsonOkWithETag(c, gin.H{"hash": c.Param("id")})
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/blocks/abc", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	etag := resp.Header().Get("ETag")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, `{"hash":"abc"}`, resp.Body.String())
+	assert.Len(t, etag, 18)
+
+	req = httptest.NewRequest(http.MethodGet, "/blocks/abc", nil)
+	req.Header.Set("If-None-Match", etag)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNotModified, resp.Code)
+	assert.Equal(t, etag, resp.Header().Get("ETag"))
+	assert.Empty(t, resp.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/blocks/def", nil)
+	req.Header.Set("If-None-Match", etag)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.NotEqual(t, etag, resp.Header().Get("ETag"))
+}
+
+func TestBadRequestValidationDetails(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/blocks", func(c *gin.Context) {
+		search := &store.BlockSearch{Sort: "time", Limit: 1000}
+		badRequest(c, search.Validate())
+	})
+	router.GET("/epochs/:id", func(c *gin.Context) {
+		badRequest(c, "epoch must be a number")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/blocks", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.JSONEq(t, `{
+		"status": 400,
+		"error": "invalid sort field, max limit is 100",
+		"details": ["invalid sort field", "max limit is 100"]
+	}`, resp.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/epochs/abc", nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.JSONEq(t, `{"status": 400, "error": "epoch must be a number"}`, resp.Body.String())
+}
+
+func TestNewErrorResponseGraphErrors(t *testing.T) {
+	err := graph.ResponseError{Errors: []graph.GraphError{
+		{Message: "Invalid signature"},
+		{Message: "Insufficient funds"},
+	}}
+
+	resp := newErrorResponse(http.StatusUnprocessableEntity, err)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.Status)
+	assert.Equal(t, "Invalid signature", resp.Error)
+	assert.Equal(t, []string{"Invalid signature", "Insufficient funds"}, resp.Details)
+}
@@ -3,42 +3,86 @@ package server
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 
+	"github.com/figment-networks/mina-indexer/client/archive"
 	"github.com/figment-networks/mina-indexer/client/graph"
 	"github.com/figment-networks/mina-indexer/config"
+	"github.com/figment-networks/mina-indexer/indexing"
 	"github.com/figment-networks/mina-indexer/model"
+	"github.com/figment-networks/mina-indexer/model/mapper"
+	"github.com/figment-networks/mina-indexer/model/types"
+	"github.com/figment-networks/mina-indexer/model/util"
 	"github.com/figment-networks/mina-indexer/store"
 )
 
+const (
+	unlockScheduleLimit  = 100
+	accountsBatchLimit   = 100
+	totalStakedCacheTTL  = time.Minute * 5
+	pendingCountTimeout  = time.Second * 5
+	mempoolCacheTTL      = time.Second * 5
+	mempoolTimeout       = time.Second * 5
+	epochDataCacheTTL    = time.Hour
+	networkSummaryTTL    = time.Minute * 5
+	partialSummaryTTL    = time.Second * 30
+	networkIdentityTTL   = time.Hour
+	accountRolesCacheTTL = time.Minute
+	delegatorsHistoryTTL = time.Minute * 10
+	sendTxTimeout        = time.Second * 10
+)
+
 // Server handles HTTP requests
 type Server struct {
 	*gin.Engine
 
-	graphClient *graph.Client
-	db          *store.Store
-	log         *logrus.Logger
+	graphClient   *graph.Client
+	archiveClient archive.Client
+	db            *store.Store
+	log           *logrus.Logger
+	cache         *memoryCache
+
+	healthCheckNode bool
 }
 
 // New returns a new server instance
-func New(db *store.Store, cfg *config.Config, logger *logrus.Logger) *Server {
+func New(db *store.Store, graphClient *graph.Client, cfg *config.Config, logger *logrus.Logger) *Server {
 	s := &Server{
 		Engine: gin.New(),
 
 		db:          db,
-		graphClient: graph.NewDefaultClient(cfg.MinaEndpoint),
+		graphClient: graphClient,
 		log:         logger,
+		cache:       newMemoryCache(),
+
+		healthCheckNode: cfg.HealthCheckNode,
 	}
 
 	s.initMiddleware(cfg)
 	s.initRoutes()
 
+	if cfg.AdminToken != "" {
+		admin := s.Group("/admin", adminAuthMiddleware(cfg.AdminToken))
+		admin.POST("/cache/invalidate", s.InvalidateCache)
+		admin.GET("/failed_blocks", s.GetFailedBlocks)
+		admin.POST("/failed_blocks/:height/retry", s.RetryFailedBlock)
+	}
+
 	return s
 }
 
+// SetArchiveClient sets the archive client used to retry failed blocks
+func (s *Server) SetArchiveClient(client archive.Client) {
+	s.archiveClient = client
+}
+
 func (s *Server) initRoutes() {
 	s.GET("/health", s.GetHealth)
 	s.GET("/status", s.GetStatus)
@@ -47,19 +91,44 @@ func (s *Server) initRoutes() {
 	s.GET("/blocks", s.GetBlocks)
 	s.GET("/blocks/:id", s.GetBlock)
 	s.GET("/blocks/:id/transactions", s.GetBlockTransactions)
+	s.GET("/blocks/:id/epoch_data", s.GetBlockEpochData)
+	s.GET("/blocks/:id/snarkers", s.GetBlockSnarkers)
+	s.GET("/blocks/height/:height/transactions", s.GetBlockTransactionsByHeight)
 	s.GET("/block_times", s.GetBlockTimes)
+	s.GET("/block_times/series", s.GetBlockTimesSeries)
+	s.GET("/search/blocks", s.SearchBlocks)
 	s.GET("/block_stats", timeBucketMiddleware(), s.GetBlockStats)
 	s.GET("/chain_stats", timeBucketMiddleware(), s.GetBlockStats)
 	s.GET("/validators", s.GetValidators)
 	s.GET("/validators/:id", s.GetValidator)
 	s.GET("/validators/:id/stats", timeBucketMiddleware(), s.GetValidatorStats)
+	s.GET("/validators/:id/schedule", s.GetValidatorSchedule)
+	s.GET("/validators/:id/voting_power", s.GetValidatorVotingPower)
+	s.GET("/validators/:id/delegators/history", s.GetValidatorDelegatorsHistory)
 	s.GET("/delegations", s.GetDelegations)
 	s.GET("/snarkers", s.GetSnarkers)
 	s.GET("/snarker/:id", s.GetSnarker)
+	s.GET("/epochs/:id", s.GetEpoch)
+	s.GET("/epochs/:id/blocks", s.GetEpochBlocks)
+	s.GET("/epochs/:id/snarkers", s.GetEpochSnarkers)
+	s.GET("/epochs/:id/validators", s.GetEpochValidators)
 	s.GET("/transactions", s.GetTransactions)
+	s.POST("/transactions", s.SendTransaction)
 	s.GET("/pending_transactions", s.GetPendingTransactions)
+	s.GET("/mempool", s.GetMempool)
+	s.GET("/transactions/by_memo/:memo_hash", s.GetTransactionsByMemo)
 	s.GET("/transactions/:id", s.GetTransaction)
+	s.GET("/transactions/:id/receipt", s.GetTransactionReceipt)
+	s.GET("/accounts/new", s.GetNewAccounts)
+	s.POST("/accounts/batch", s.GetAccountsBatch)
 	s.GET("/accounts/:id", s.GetAccount)
+	s.GET("/accounts/:id/unlock_schedule", s.GetAccountUnlockSchedule)
+	s.GET("/accounts/:id/staking_history", s.GetAccountStakingHistory)
+	s.GET("/accounts/:id/tokens", s.GetAccountTokens)
+	s.GET("/accounts/:id/delegations", s.GetAccountDelegations)
+	s.GET("/network/stats", s.GetNetworkStats)
+	s.GET("/stats/network", s.GetNetworkSummary)
+	s.GET("/stats/transaction_volume", s.GetTransactionVolume)
 	s.GET("/ledgers", s.GetLedgers)
 	s.GET("/ledger", s.GetLedger)
 }
@@ -67,9 +136,21 @@ func (s *Server) initRoutes() {
 func (s *Server) initMiddleware(cfg *config.Config) {
 	s.Use(gin.Recovery())
 	s.Use(requestLoggerMiddleware(logrus.StandardLogger()))
+	s.Use(securityHeadersMiddleware())
 
-	if cfg.IsDevelopment() {
-		s.Use(corsMiddleware())
+	if cfg.MaxBodyBytes > 0 {
+		s.Use(bodySizeMiddleware(cfg.MaxBodyBytes))
+	}
+	if cfg.MaxResponseBodyBytes > 0 {
+		s.Use(responseSizeMiddleware(cfg.MaxResponseBodyBytes))
+	}
+
+	allowedOrigins := cfg.CORSAllowedOrigins
+	if len(allowedOrigins) == 0 && cfg.IsDevelopment() {
+		allowedOrigins = []string{"*"}
+	}
+	if len(allowedOrigins) > 0 {
+		s.Use(corsMiddleware(allowedOrigins))
 	}
 
 	if cfg.RollbarToken != "" {
@@ -79,12 +160,48 @@ func (s *Server) initMiddleware(cfg *config.Config) {
 
 // GetHealth renders the server health status
 func (s Server) GetHealth(c *gin.Context) {
-	resp := HealthResponse{Healthy: true}
+	resp := HealthResponse{
+		Healthy:    true,
+		Components: map[string]string{},
+	}
+	failures := 0
 
 	if err := s.db.Test(); err != nil {
 		s.log.WithError(err).Error("database check error")
+		resp.Components["db"] = "error"
+		failures++
+	} else {
+		resp.Components["db"] = "ok"
+	}
+
+	if s.db.HasReplica() {
+		if err := s.db.TestReplica(); err != nil {
+			s.log.WithError(err).Error("database replica check error")
+			resp.Components["db_replica"] = "error"
+			failures++
+		} else {
+			resp.Components["db_replica"] = "ok"
+		}
+	}
+
+	if s.healthCheckNode {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+		defer cancel()
+
+		if _, err := s.graphClient.GetDaemonStatus(ctx); err != nil {
+			s.log.WithError(err).Error("node check error")
+			resp.Components["node"] = "degraded"
+			failures++
+		} else {
+			resp.Components["node"] = "ok"
+		}
+	}
+
+	if failures > 0 {
 		resp.Healthy = false
-		jsonResponse(c, 500, resp)
+	}
+	if failures == len(resp.Components) {
+		jsonResponse(c, http.StatusServiceUnavailable, resp)
 		return
 	}
 
@@ -94,11 +211,12 @@ func (s Server) GetHealth(c *gin.Context) {
 // GetStatus returns the status of the service
 func (s Server) GetStatus(c *gin.Context) {
 	resp := StatusResponse{
-		AppName:    config.AppName,
-		AppVersion: config.AppVersion,
-		GitCommit:  config.GitCommit,
-		GoVersion:  config.GoVersion,
-		SyncStatus: "stale",
+		AppName:        config.AppName,
+		AppVersion:     config.AppVersion,
+		IndexerVersion: config.IndexerVersion,
+		GitCommit:      config.GitCommit,
+		GoVersion:      config.GoVersion,
+		SyncStatus:     "stale",
 	}
 
 	// Fetch node status as quickly as possible.
@@ -115,15 +233,17 @@ func (s Server) GetStatus(c *gin.Context) {
 		resp.NodeError = true
 	}
 
-	if block, err := s.db.Blocks.Recent(); err == nil {
+	blocks, err := s.db.Blocks.RecentN(1)
+	if err != nil {
+		logrus.WithError(err).Error("recent block fetch failed")
+	} else if len(blocks) > 0 {
+		block := blocks[0]
 		resp.LastBlockTime = block.Time
 		resp.LastBlockHeight = block.Height
 
 		if time.Since(block.Time).Minutes() <= 30 {
 			resp.SyncStatus = "current"
 		}
-	} else {
-		logrus.WithError(err).Error("recent block fetch failed")
 	}
 
 	jsonOk(c, resp)
@@ -151,8 +271,9 @@ func (s *Server) GetCurrentBlock(c *gin.Context) {
 	jsonOk(c, block)
 }
 
-// GetBlock returns a single block
-func (s *Server) GetBlock(c *gin.Context) {
+// findBlock returns the block matching the height or hash of the id param.
+// It renders the error response and returns nil when the lookup fails.
+func (s *Server) findBlock(c *gin.Context) *model.Block {
 	var block *model.Block
 	var err error
 
@@ -160,13 +281,23 @@ func (s *Server) GetBlock(c *gin.Context) {
 	if id.IsNumeric() {
 		if id.UInt64() == 0 {
 			badRequest(c, errors.New("height must be greater than 0"))
-			return
+			return nil
 		}
 		block, err = s.db.Blocks.FindByHeight(id.UInt64())
 	} else {
 		block, err = s.db.Blocks.FindByHash(id.String())
 	}
 	if shouldReturn(c, err) {
+		return nil
+	}
+
+	return block
+}
+
+// GetBlock returns a single block
+func (s *Server) GetBlock(c *gin.Context) {
+	block := s.findBlock(c)
+	if block == nil {
 		return
 	}
 
@@ -189,24 +320,56 @@ func (s *Server) GetBlock(c *gin.Context) {
 		return
 	}
 
-	jsonOk(c, BlockResponse{
+	snarkers, err := s.db.Snarkers.ActiveInBlock(block.Hash)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	jsonOkWithETag(c, BlockResponse{
 		Block:        block,
 		Creator:      creator,
 		Transactions: transactions,
 		SnarkJobs:    jobs,
+		Snarkers:     snarkers,
 	})
 }
 
+// GetBlockSnarkers returns the snarkers with jobs included in the block
+func (s *Server) GetBlockSnarkers(c *gin.Context) {
+	block := s.findBlock(c)
+	if block == nil {
+		return
+	}
+
+	snarkers, err := s.db.Snarkers.ActiveInBlock(block.Hash)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	jsonOk(c, snarkers)
+}
+
 func (s *Server) GetBlockTransactions(c *gin.Context) {
+	block := s.findBlock(c)
+	if block == nil {
+		return
+	}
+
+	transactions, err := s.db.Transactions.ByHeight(block.Height, uint(block.TransactionsCount))
+	if shouldReturn(c, err) {
+		return
+	}
+
+	jsonOk(c, transactions)
+}
+
+// GetBlockEpochData returns the epoch context of a block
+func (s *Server) GetBlockEpochData(c *gin.Context) {
 	var block *model.Block
 	var err error
 
 	id := resourceID(c, "id")
 	if id.IsNumeric() {
-		if id.UInt64() == 0 {
-			badRequest(c, errors.New("height must be greater than 0"))
-			return
-		}
 		block, err = s.db.Blocks.FindByHeight(id.UInt64())
 	} else {
 		block, err = s.db.Blocks.FindByHash(id.String())
@@ -215,6 +378,54 @@ func (s *Server) GetBlockTransactions(c *gin.Context) {
 		return
 	}
 
+	cacheKey := "epoch_data:" + block.Hash
+	if val, ok := s.cache.Get(cacheKey); ok {
+		jsonOk(c, val)
+		return
+	}
+
+	count, err := s.db.Blocks.CountInEpoch(block.Epoch, block.Height)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	slotsRemaining := model.SlotsPerEpoch - 1 - block.Slot%model.SlotsPerEpoch
+
+	// Every height has a single canonical block, so the epoch blocks have consecutive heights
+	var startHeight uint64
+	if count > 0 {
+		startHeight = block.Height - uint64(count) + 1
+	}
+
+	result := BlockEpochDataResponse{
+		Epoch:                   block.Epoch,
+		SlotsRemainingInEpoch:   slotsRemaining,
+		TotalBlocksInEpochSoFar: count,
+		EpochStartHeight:        startHeight,
+		EpochEndEstimatedHeight: block.Height + uint64(float64(slotsRemaining)*model.ActiveSlotsCoefficient),
+	}
+
+	// Only canonical blocks have a stable position within the epoch
+	if block.Canonical {
+		s.cache.Set(cacheKey, result, epochDataCacheTTL)
+	}
+
+	jsonOk(c, result)
+}
+
+// GetBlockTransactionsByHeight returns transactions of the canonical block at a height
+func (s *Server) GetBlockTransactionsByHeight(c *gin.Context) {
+	height := resourceID(c, "height")
+	if !height.IsNumeric() || height.UInt64() == 0 {
+		badRequest(c, errors.New("height must be greater than 0"))
+		return
+	}
+
+	block, err := s.db.Blocks.FindByHeight(height.UInt64())
+	if shouldReturn(c, err) {
+		return
+	}
+
 	transactions, err := s.db.Transactions.ByHeight(block.Height, uint(block.TransactionsCount))
 	if shouldReturn(c, err) {
 		return
@@ -237,7 +448,28 @@ func (s *Server) GetBlocks(c *gin.Context) {
 		return
 	}
 
-	blocks, err := s.db.Blocks.Search(search)
+	blocks, count, err := s.db.Blocks.Search(search)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	setTotalCount(c, count)
+	jsonOk(c, blocks)
+}
+
+// SearchBlocks returns blocks matching the creator or hash text, most relevant first
+func (s *Server) SearchBlocks(c *gin.Context) {
+	params := blocksFullSearchParams{}
+	if err := c.BindQuery(&params); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if err := params.validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	blocks, err := s.db.Blocks.SearchFull(params.Query, params.Limit)
 	if shouldReturn(c, err) {
 		return
 	}
@@ -264,6 +496,26 @@ func (s *Server) GetBlockTimes(c *gin.Context) {
 	jsonOk(c, result)
 }
 
+// GetBlockTimesSeries renders the average block times grouped by hour or day
+func (s *Server) GetBlockTimesSeries(c *gin.Context) {
+	params := blockTimesSeriesParams{}
+	if err := c.BindQuery(&params); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if err := params.validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	series, err := s.db.Blocks.BlockTimesSeries(params.Window, params.Bucket)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	jsonOk(c, series)
+}
+
 // GetBlockStats returns block stats for an interval
 func (s *Server) GetBlockStats(c *gin.Context) {
 	tb := c.MustGet("timebucket").(timeBucket)
@@ -289,12 +541,125 @@ func (s *Server) GetTransaction(c *gin.Context) {
 		return
 	}
 
-	jsonOk(c, tran)
+	params := transactionParams{}
+	if err := c.BindQuery(&params); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if !params.Enrich {
+		jsonOkWithETag(c, tran)
+		return
+	}
+
+	result := TransactionResponse{Transaction: tran}
+	if tran.Sender != nil {
+		if result.SenderAccount, err = s.findAccount(*tran.Sender); shouldReturn(c, err) {
+			return
+		}
+	}
+	if result.ReceiverAccount, err = s.findAccount(tran.Receiver); shouldReturn(c, err) {
+		return
+	}
+
+	jsonOkWithETag(c, result)
+}
+
+// findAccount returns the indexed account or nil if the account is not found
+func (s *Server) findAccount(publicKey string) (*model.Account, error) {
+	account, err := s.db.Accounts.FindByPublicKey(publicKey)
+	if err == store.ErrNotFound {
+		return nil, nil
+	}
+	return account, err
+}
+
+// GetTransactionReceipt returns the transaction inclusion details
+func (s *Server) GetTransactionReceipt(c *gin.Context) {
+	hash := c.Param("id")
+
+	tran, err := s.db.Transactions.FindByHash(hash)
+	if err == store.ErrNotFound {
+		s.renderPendingReceipt(c, hash)
+		return
+	}
+	if shouldReturn(c, err) {
+		return
+	}
+
+	block, err := s.db.Blocks.FindByHeight(tran.BlockHeight)
+	if err == store.ErrNotFound || err == nil && block.Hash != tran.BlockHash {
+		s.renderPendingReceipt(c, hash)
+		return
+	}
+	if shouldReturn(c, err) {
+		return
+	}
+
+	recent, err := s.db.Blocks.Recent()
+	if shouldReturn(c, err) {
+		return
+	}
+
+	receipt := TransactionReceiptResponse{
+		Hash:        tran.Hash,
+		Status:      tran.Status,
+		BlockHash:   block.Hash,
+		BlockHeight: block.Height,
+		BlockTime:   &block.Time,
+		Index:       tran.SequenceNumber,
+	}
+	if recent.Height >= block.Height {
+		receipt.Confirmations = recent.Height - block.Height
+	}
+
+	jsonOk(c, receipt)
+}
+
+// renderPendingReceipt renders a receipt for a transaction that is not included in any indexed block
+func (s *Server) renderPendingReceipt(c *gin.Context, hash string) {
+	pending, err := s.graphClient.GetPendingTransactions()
+	if err != nil {
+		s.log.WithError(err).Error("pending transactions fetch failed")
+	}
+
+	for _, tx := range pending {
+		if tx.Hash == hash {
+			jsonResponse(c, http.StatusAccepted, TransactionReceiptResponse{
+				Hash:   hash,
+				Status: "pending",
+			})
+			return
+		}
+	}
+
+	notFound(c, store.ErrNotFound)
 }
 
 // GetValidators rendes all existing validators
 func (s *Server) GetValidators(c *gin.Context) {
-	validators, err := s.db.Validators.Index()
+	search := &store.ValidatorSearch{}
+
+	if err := c.BindQuery(search); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	nameOnly := search.IsNameOnly()
+	if err := search.Validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	if nameOnly {
+		validators, err := s.db.Validators.SearchByName(search.Name)
+		if shouldReturn(c, err) {
+			return
+		}
+		jsonOk(c, validators)
+		return
+	}
+
+	validators, err := s.db.Validators.Search(search)
 	if shouldReturn(c, err) {
 		return
 	}
@@ -303,6 +668,16 @@ func (s *Server) GetValidators(c *gin.Context) {
 
 // GetValidator renders the validator details
 func (s *Server) GetValidator(c *gin.Context) {
+	params := validatorStatsParams{}
+	if err := c.BindQuery(&params); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if err := params.validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
 	validator, err := s.db.Validators.FindByPublicKey(c.Param("id"))
 	if shouldReturn(c, err) {
 		return
@@ -323,6 +698,11 @@ func (s *Server) GetValidator(c *gin.Context) {
 		return
 	}
 
+	stats, err := s.db.Stats.ValidatorStats(validator, params.period(), params.interval())
+	if shouldReturn(c, err) {
+		return
+	}
+
 	stats30d, err := s.db.Stats.ValidatorStats(validator, 30, store.BucketDay)
 	if shouldReturn(c, err) {
 		return
@@ -337,89 +717,402 @@ func (s *Server) GetValidator(c *gin.Context) {
 		Validator:   validator,
 		Account:     account,
 		Delegations: delegations,
-		Stats:       stats30d,
+		Stats:       stats,
 		StatsHourly: stats24h,
 		StatsDaily:  stats30d,
 	})
 }
 
-// GetValidatorStats renders validator stats for a given time bucket
-func (s *Server) GetValidatorStats(c *gin.Context) {
-	tb := c.MustGet("timebucket").(timeBucket)
-
-	validator, err := s.db.Validators.FindByPublicKey(c.Param("id"))
-	if shouldReturn(c, err) {
+// GetValidatorDelegatorsHistory renders the delegators changes of a validator between
+// consecutive staking ledgers, most recent epoch first
+func (s *Server) GetValidatorDelegatorsHistory(c *gin.Context) {
+	params := delegatorsHistoryParams{}
+	if err := c.BindQuery(&params); err != nil {
+		badRequest(c, err)
 		return
 	}
-
-	stats, err := s.db.Stats.ValidatorStats(validator, tb.Period, tb.Interval)
-	if shouldReturn(c, err) {
+	if err := params.validate(); err != nil {
+		badRequest(c, err)
 		return
 	}
 
-	jsonOk(c, stats)
-}
-
-// GetDelegations rendes all existing delegations
-func (s *Server) GetDelegations(c *gin.Context) {
-	delegations, err := s.db.Staking.FindDelegations(store.FindDelegationsParams{
-		PublicKey: c.Query("public_key"),
-		Delegate:  c.Query("delegate"),
-	})
-	if err != store.ErrNotFound && shouldReturn(c, err) {
+	validatorKey := c.Param("id")
+	if !rePublicKey.MatchString(validatorKey) {
+		badRequest(c, errors.New("invalid public key"))
 		return
 	}
 
-	jsonOk(c, delegations)
-}
-
-// GetSnarkers renders all existing snarkers
-func (s *Server) GetSnarkers(c *gin.Context) {
-	snarkers, err := s.db.Snarkers.All()
-	if shouldReturn(c, err) {
+	// Only known validators and epochs are looked up and cached
+	if _, err := s.db.Validators.FindByPublicKey(validatorKey); shouldReturn(c, err) {
 		return
 	}
-	jsonOk(c, snarkers)
-}
 
-// GetSnarker get snarker info for canonical
-func (s *Server) GetSnarker(c *gin.Context) {
-	snarker, err := s.db.Snarkers.FindSnarker(c.Param("id"))
-	if shouldReturn(c, err) {
-		return
+	epochKey := "latest"
+	if params.Epoch != nil {
+		if _, err := s.db.Staking.FindLedger(*params.Epoch); shouldReturn(c, err) {
+			return
+		}
+		epochKey = strconv.Itoa(*params.Epoch)
 	}
 
-	result, err := s.db.Snarkers.SnarkerInfoFromCanonicalBlocks(snarker.Account, snarker.StartHeight, snarker.LastHeight)
-	if err != nil {
-		badRequest(c, err)
+	cacheKey := fmt.Sprintf("delegators_history:%s:%s:%d", validatorKey, epochKey, params.Limit)
+	if val, ok := s.cache.Get(cacheKey); ok {
+		jsonOk(c, val)
 		return
 	}
 
-	jsonOk(c, result)
-}
-
-// GetTransactions returns transactions by height
-func (s *Server) GetTransactions(c *gin.Context) {
-	search := store.TransactionSearch{}
-	if err := c.BindQuery(&search); err != nil {
-		badRequest(c, err)
+	ledgers, err := s.db.Staking.AllLedgers()
+	if shouldReturn(c, err) {
 		return
 	}
 
-	if err := search.Validate(); err != nil {
-		badRequest(c, err)
-		return
+	epochs := []int{}
+	for _, ledger := range ledgers {
+		if params.Epoch != nil && ledger.Epoch > *params.Epoch {
+			break
+		}
+		if len(epochs) == 0 || epochs[len(epochs)-1] != ledger.Epoch {
+			epochs = append(epochs, ledger.Epoch)
+		}
 	}
 
-	transactions, err := s.db.Transactions.Search(search)
-	if shouldReturn(c, err) {
-		return
+	history := []model.DelegationDiff{}
+	for i := len(epochs) - 1; i > 0 && len(history) < params.Limit; i-- {
+		diff, err := s.db.Staking.DelegationDiff(validatorKey, epochs[i-1], epochs[i])
+		if shouldReturn(c, err) {
+			return
+		}
+		history = append(history, *diff)
 	}
+	s.cache.Set(cacheKey, history, delegatorsHistoryTTL)
 
-	jsonOk(c, transactions)
+	jsonOk(c, history)
 }
 
-// GetPendingTransactions returns transactions by height
+// GetValidatorSchedule renders the expected block production of a validator in an epoch
+func (s *Server) GetValidatorSchedule(c *gin.Context) {
+	input := &LedgerRequest{}
+	if err := c.BindQuery(input); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	var (
+		ledger *model.Ledger
+		err    error
+	)
+	if input.Epoch != nil {
+		ledger, err = s.db.Staking.FindLedger(*input.Epoch)
+	} else {
+		ledger, err = s.db.Staking.LastLedger()
+	}
+	if shouldReturn(c, err) {
+		return
+	}
+
+	stake, err := s.db.Staking.DelegatedStake(ledger.ID, c.Param("id"))
+	if shouldReturn(c, err) {
+		return
+	}
+
+	weight := stake.Delegated.PercentOf(stake.Total) / 100
+	expected, low, high := util.ExpectedBlocks(weight, model.ExpectedEpochBlocks)
+
+	jsonOk(c, ValidatorScheduleResponse{
+		Epoch:          ledger.Epoch,
+		StakeWeight:    weight,
+		ExpectedBlocks: expected,
+		CILower:        low,
+		CIUpper:        high,
+	})
+}
+
+// GetValidatorVotingPower renders the share of the epoch staking ledger delegated to a validator
+func (s *Server) GetValidatorVotingPower(c *gin.Context) {
+	validatorKey := c.Param("id")
+	if !rePublicKey.MatchString(validatorKey) {
+		badRequest(c, errors.New("invalid public key"))
+		return
+	}
+
+	input := &LedgerRequest{}
+	if err := c.BindQuery(input); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	var (
+		ledger *model.Ledger
+		err    error
+	)
+	if input.Epoch != nil {
+		ledger, err = s.db.Staking.FindLedger(*input.Epoch)
+	} else {
+		ledger, err = s.db.Staking.LastLedger()
+	}
+	if shouldReturn(c, err) {
+		return
+	}
+
+	stake, err := s.db.Staking.DelegatedStake(ledger.ID, validatorKey)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	jsonOk(c, VotingPowerResponse{
+		Validator:          validatorKey,
+		Epoch:              ledger.Epoch,
+		Stake:              stake.Delegated,
+		TotalStake:         stake.Total,
+		VotingPowerPercent: util.VotingPower(stake.Delegated, stake.Total),
+	})
+}
+
+// GetValidatorStats renders validator stats for a given time bucket
+func (s *Server) GetValidatorStats(c *gin.Context) {
+	tb := c.MustGet("timebucket").(timeBucket)
+
+	validator, err := s.db.Validators.FindByPublicKey(c.Param("id"))
+	if shouldReturn(c, err) {
+		return
+	}
+
+	stats, err := s.db.Stats.ValidatorStats(validator, tb.Period, tb.Interval)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	jsonOk(c, stats)
+}
+
+// GetDelegations rendes all existing delegations
+func (s *Server) GetDelegations(c *gin.Context) {
+	delegations, err := s.db.Staking.FindDelegations(store.FindDelegationsParams{
+		PublicKey: c.Query("public_key"),
+		Delegate:  c.Query("delegate"),
+	})
+	if err != store.ErrNotFound && shouldReturn(c, err) {
+		return
+	}
+
+	jsonOk(c, delegations)
+}
+
+// GetSnarkers renders existing snarkers matching the search params
+func (s *Server) GetSnarkers(c *gin.Context) {
+	search := &store.SnarkerSearch{}
+
+	if err := c.BindQuery(search); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	feeRangeOnly := search.IsFeeRangeOnly()
+	if err := search.Validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	var (
+		snarkers []model.Snarker
+		err      error
+	)
+	if feeRangeOnly {
+		snarkers, err = s.db.Snarkers.ByFeeRange(search.FeeRange())
+	} else {
+		snarkers, err = s.db.Snarkers.Search(search)
+	}
+	if shouldReturn(c, err) {
+		return
+	}
+	jsonOk(c, snarkers)
+}
+
+// GetEpochSnarkers renders snarkers active during the epoch
+func (s *Server) GetEpochSnarkers(c *gin.Context) {
+	id := resourceID(c, "id")
+	if !id.IsNumeric() {
+		badRequest(c, "epoch must be a number")
+		return
+	}
+
+	snarkers, err := s.db.Snarkers.ByEpoch(int(id.Int64()))
+	if shouldReturn(c, err) {
+		return
+	}
+
+	jsonOk(c, snarkers)
+}
+
+// GetEpochValidators returns validators ranked by blocks produced in the epoch
+func (s *Server) GetEpochValidators(c *gin.Context) {
+	id := resourceID(c, "id")
+	if !id.IsNumeric() {
+		badRequest(c, "epoch must be a number")
+		return
+	}
+
+	params := epochValidatorsParams{}
+	if err := c.BindQuery(&params); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if err := params.validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	validators, err := s.db.Validators.TopByBlocksInEpoch(id.String(), params.Limit)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	jsonOk(c, validators)
+}
+
+// GetEpoch returns the epoch details
+func (s *Server) GetEpoch(c *gin.Context) {
+	id := resourceID(c, "id")
+	if !id.IsNumeric() {
+		badRequest(c, "epoch must be a number")
+		return
+	}
+
+	count, err := s.db.Blocks.CountByEpoch(id.String())
+	if shouldReturn(c, err) {
+		return
+	}
+	if count == 0 {
+		notFound(c, store.ErrNotFound)
+		return
+	}
+
+	jsonOk(c, EpochResponse{
+		Epoch:      int(id.UInt64()),
+		BlockCount: count,
+	})
+}
+
+// GetEpochBlocks returns a page of canonical blocks of the epoch
+func (s *Server) GetEpochBlocks(c *gin.Context) {
+	id := resourceID(c, "id")
+	if !id.IsNumeric() {
+		badRequest(c, "epoch must be a number")
+		return
+	}
+
+	params := epochBlocksParams{}
+	if err := c.BindQuery(&params); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if err := params.validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	blocks, err := s.db.Blocks.ByEpoch(id.String(), params.Limit, params.Offset)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	jsonOk(c, blocks)
+}
+
+// GetSnarker get snarker info for canonical
+func (s *Server) GetSnarker(c *gin.Context) {
+	snarker, err := s.db.Snarkers.FindSnarker(c.Param("id"))
+	if shouldReturn(c, err) {
+		return
+	}
+
+	result, err := s.db.Snarkers.SnarkerInfoFromCanonicalBlocks(snarker.Account, snarker.StartHeight, snarker.LastHeight)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	jsonOk(c, result)
+}
+
+// GetTransactions returns transactions by height
+func (s *Server) GetTransactions(c *gin.Context) {
+	search := store.TransactionSearch{}
+	if err := c.BindQuery(&search); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	if err := search.Validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	transactions, count, err := s.db.Transactions.Search(search)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	setTotalCount(c, count)
+	jsonOk(c, transactions)
+}
+
+// GetTransactionsByMemo returns transactions with a given memo digest
+func (s *Server) GetTransactionsByMemo(c *gin.Context) {
+	hash := strings.ToLower(c.Param("memo_hash"))
+	if !reMemoHash.MatchString(hash) {
+		badRequest(c, errInvalidMemoHash)
+		return
+	}
+
+	params := memoTransactionsParams{}
+	if err := c.BindQuery(&params); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if err := params.validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	transactions, err := s.db.Transactions.ByMemoHash(hash, params.Limit, params.Offset)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	jsonOk(c, transactions)
+}
+
+// SendTransaction broadcasts a signed payment through the node
+func (s *Server) SendTransaction(c *gin.Context) {
+	params := sendTransactionParams{}
+	if err := c.ShouldBindJSON(&params); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if err := params.validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), sendTxTimeout)
+	defer cancel()
+
+	result, err := s.graphClient.SendSignedTransaction(ctx, params.paymentInput(), params.signature())
+	if err != nil {
+		var graphErr graph.ResponseError
+		if errors.As(err, &graphErr) {
+			jsonError(c, http.StatusUnprocessableEntity, graphErr)
+			return
+		}
+		serverError(c, err)
+		return
+	}
+
+	jsonOk(c, result)
+}
+
+// GetPendingTransactions returns transactions by height
 func (s *Server) GetPendingTransactions(c *gin.Context) {
 	transactions, err := s.graphClient.GetPendingTransactions()
 	if shouldReturn(c, err) {
@@ -428,6 +1121,40 @@ func (s *Server) GetPendingTransactions(c *gin.Context) {
 	jsonOk(c, transactions)
 }
 
+// GetMempool returns the transactions pending in the node transaction pool
+func (s *Server) GetMempool(c *gin.Context) {
+	if val, ok := s.cache.Get("mempool"); ok {
+		entry := val.(mempoolCacheEntry)
+		c.Header("X-Cache-Age", strconv.Itoa(int(time.Since(entry.fetchedAt).Seconds())))
+		jsonOk(c, entry.transactions)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), mempoolTimeout)
+	defer cancel()
+
+	transactions := []model.Transaction{}
+
+	pooled, err := s.graphClient.GetPooledTransactions(ctx)
+	if err != nil {
+		s.log.WithError(err).Warn("pooled transactions fetch failed")
+		jsonOk(c, transactions)
+		return
+	}
+
+	for idx := range pooled {
+		transactions = append(transactions, *mapper.PendingTransaction(&pooled[idx]))
+	}
+
+	s.cache.Set("mempool", mempoolCacheEntry{
+		transactions: transactions,
+		fetchedAt:    time.Now(),
+	}, mempoolCacheTTL)
+
+	c.Header("X-Cache-Age", "0")
+	jsonOk(c, transactions)
+}
+
 // GetAccount returns account for by hash or ID
 func (s *Server) GetAccount(c *gin.Context) {
 	var (
@@ -445,7 +1172,372 @@ func (s *Server) GetAccount(c *gin.Context) {
 		return
 	}
 
-	jsonOk(c, acc)
+	totalSent, err := s.db.Transactions.SumAmountBySender(acc.PublicKey)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	acc.Tokens, err = s.db.Accounts.TokenBalances(acc.PublicKey)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	roles, err := s.accountRoles(acc.PublicKey)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	resp := AccountResponse{
+		Account:     acc,
+		TotalSent:   totalSent,
+		IsValidator: roles.isValidator,
+		IsSnarker:   roles.isSnarker,
+	}
+
+	if acc.Delegate != nil {
+		delegate, err := s.findAccount(*acc.Delegate)
+		if shouldReturn(c, err) {
+			return
+		}
+		if delegate != nil {
+			resp.DelegateAccount = &AccountSummary{
+				PublicKey: delegate.PublicKey,
+				Balance:   delegate.Balance,
+				Stake:     delegate.Stake,
+			}
+		}
+	}
+
+	jsonOk(c, resp)
+}
+
+type accountRoles struct {
+	isValidator bool
+	isSnarker   bool
+}
+
+// accountRoles returns whether the account is a known validator or snarker
+func (s *Server) accountRoles(publicKey string) (accountRoles, error) {
+	cacheKey := "account_roles:" + publicKey
+	if val, ok := s.cache.Get(cacheKey); ok {
+		return val.(accountRoles), nil
+	}
+
+	roles := accountRoles{}
+
+	_, err := s.db.Validators.FindByPublicKey(publicKey)
+	if err != nil && err != store.ErrNotFound {
+		return roles, err
+	}
+	roles.isValidator = err == nil
+
+	_, err = s.db.Snarkers.FindByPublicKey(publicKey)
+	if err != nil && err != store.ErrNotFound {
+		return roles, err
+	}
+	roles.isSnarker = err == nil
+
+	s.cache.Set(cacheKey, roles, accountRolesCacheTTL)
+	return roles, nil
+}
+
+// GetNewAccounts returns accounts first seen on chain since a given date
+func (s *Server) GetNewAccounts(c *gin.Context) {
+	params := newAccountsParams{}
+	if err := c.BindQuery(&params); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if err := params.validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	accounts, err := s.db.Accounts.CreatedSince(params.Since, params.Limit, params.Offset)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	jsonOk(c, accounts)
+}
+
+// GetAccountUnlockSchedule returns the upcoming vesting events of a timed account
+func (s *Server) GetAccountUnlockSchedule(c *gin.Context) {
+	ledger, err := s.db.Staking.LastLedger()
+	if shouldReturn(c, err) {
+		return
+	}
+
+	entry, err := s.db.Staking.FindLedgerEntry(ledger.ID, c.Param("id"))
+	if shouldReturn(c, err) {
+		return
+	}
+
+	block, err := s.db.Blocks.Recent()
+	if shouldReturn(c, err) {
+		return
+	}
+
+	jsonOk(c, model.UnlockSchedule(*entry, uint64(block.Slot), block.Time, unlockScheduleLimit))
+}
+
+// GetAccountsBatch returns accounts for multiple public keys
+func (s *Server) GetAccountsBatch(c *gin.Context) {
+	input := AccountsBatchRequest{}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if len(input.PublicKeys) > accountsBatchLimit {
+		badRequest(c, fmt.Sprintf("max number of public keys is %d", accountsBatchLimit))
+		return
+	}
+
+	accounts, err := s.db.Accounts.FindByPublicKeys(input.PublicKeys)
+	if err != nil && err != store.ErrNotFound {
+		serverError(c, err)
+		return
+	}
+
+	resp := AccountsBatchResponse{
+		Accounts: map[string]model.Account{},
+		Missing:  []string{},
+	}
+	for _, acc := range accounts {
+		resp.Accounts[acc.PublicKey] = acc
+	}
+	for _, key := range input.PublicKeys {
+		if _, ok := resp.Accounts[key]; !ok {
+			resp.Missing = append(resp.Missing, key)
+		}
+	}
+
+	jsonOk(c, resp)
+}
+
+// GetAccountTokens returns the custom token balances of an account
+func (s *Server) GetAccountTokens(c *gin.Context) {
+	publicKey := c.Param("id")
+	if !rePublicKey.MatchString(publicKey) {
+		badRequest(c, errors.New("invalid public key"))
+		return
+	}
+
+	tokens, err := s.db.Accounts.TokenBalances(publicKey)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	jsonOk(c, tokens)
+}
+
+// GetAccountStakingHistory returns the epoch staking ledger records of an account
+func (s *Server) GetAccountStakingHistory(c *gin.Context) {
+	params := stakingHistoryParams{}
+	if err := c.BindQuery(&params); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if err := params.validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	history, err := s.db.Staking.AccountHistory(c.Param("id"), params.Limit, params.After)
+	if err != nil && err != store.ErrNotFound {
+		serverError(c, err)
+		return
+	}
+
+	jsonOk(c, history)
+}
+
+// GetAccountDelegations returns the delegation change history of an account
+func (s *Server) GetAccountDelegations(c *gin.Context) {
+	params := delegationHistoryParams{}
+	if err := c.BindQuery(&params); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if err := params.validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	history, err := s.db.Transactions.DelegationHistory(c.Param("id"), params.Epoch)
+	if err != nil && err != store.ErrNotFound {
+		serverError(c, err)
+		return
+	}
+
+	jsonOk(c, history)
+}
+
+// GetTransactionVolume returns the daily transactions volume
+func (s *Server) GetTransactionVolume(c *gin.Context) {
+	params := transactionVolumeParams{}
+	if err := c.BindQuery(&params); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if err := params.validate(); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	volume, err := s.db.Stats.DailyTransactionVolume(params.Days)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	jsonOk(c, volume)
+}
+
+// InvalidateCache removes the cached responses matching the key
+func (s *Server) InvalidateCache(c *gin.Context) {
+	key := c.Query("key")
+	if key == "" {
+		badRequest(c, "key is required")
+		return
+	}
+
+	count := s.cache.Delete(key)
+	s.log.WithField("key", key).WithField("count", count).Info("cache invalidated")
+
+	jsonOk(c, gin.H{"invalidated": count})
+}
+
+// GetFailedBlocks renders the blocks that failed to import
+func (s *Server) GetFailedBlocks(c *gin.Context) {
+	blocks, err := s.db.FailedBlocks.All()
+	if shouldReturn(c, err) {
+		return
+	}
+	jsonOk(c, blocks)
+}
+
+// RetryFailedBlock runs the import of a failed block height again
+func (s *Server) RetryFailedBlock(c *gin.Context) {
+	if s.archiveClient == nil {
+		jsonError(c, http.StatusServiceUnavailable, errNoArchive)
+		return
+	}
+
+	height, err := strconv.ParseUint(c.Param("height"), 10, 64)
+	if err != nil {
+		badRequest(c, "height must be a number")
+		return
+	}
+
+	failed, err := s.db.FailedBlocks.FindByHeight(height)
+	if shouldReturn(c, err) {
+		return
+	}
+
+	block, err := indexing.RetryFailedBlock(s.db, s.archiveClient, s.graphClient, failed)
+	if err != nil {
+		if errors.Is(err, indexing.ErrImportFailed) {
+			jsonError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+		serverError(c, err)
+		return
+	}
+
+	s.log.WithField("height", height).Info("failed block imported")
+	jsonOk(c, block)
+}
+
+// GetNetworkStats returns the current network stats
+func (s *Server) GetNetworkStats(c *gin.Context) {
+	block, err := s.db.Blocks.Recent()
+	if shouldReturn(c, err) {
+		return
+	}
+
+	totalStaked, err := s.totalStaked()
+	if shouldReturn(c, err) {
+		return
+	}
+
+	// Fall back to the count recorded with the latest block if node is unavailable
+	ctx, cancel := context.WithTimeout(c.Request.Context(), pendingCountTimeout)
+	defer cancel()
+
+	pendingCount, err := s.graphClient.GetPendingTransactionCount(ctx)
+	if err != nil {
+		s.log.WithError(err).Warn("pending transactions count fetch failed")
+		if block.PendingTxCount != nil {
+			pendingCount = *block.PendingTxCount
+		}
+	}
+
+	jsonOk(c, NetworkStatsResponse{
+		Height:              block.Height,
+		Time:                block.Time,
+		MempoolDepth:        pendingCount,
+		MempoolPendingCount: pendingCount,
+		TotalStaked:         util.NanoMINAToMINA(totalStaked.Int),
+	})
+}
+
+// GetNetworkSummary returns the network wide totals
+func (s *Server) GetNetworkSummary(c *gin.Context) {
+	if val, ok := s.cache.Get("network_summary"); ok {
+		jsonOk(c, val)
+		return
+	}
+
+	summary, err := s.db.Stats.NetworkSummary()
+	if shouldReturn(c, err) {
+		return
+	}
+
+	// Summary without the node identity is cached briefly to pick it up once the node is back
+	result := NetworkSummaryResponse{NetworkSummary: summary}
+	ttl := partialSummaryTTL
+	if identity := s.networkIdentity(c.Request.Context()); identity != nil {
+		result.ChainID = identity.ChainID
+		result.GenesisTimestamp = identity.GenesisTimestamp
+		ttl = networkSummaryTTL
+	}
+	s.cache.Set("network_summary", result, ttl)
+
+	jsonOk(c, result)
+}
+
+// networkIdentity returns the cached network identity, or nil if the node is unavailable
+func (s *Server) networkIdentity(ctx context.Context) *graph.NetworkIdentity {
+	if val, ok := s.cache.Get("network_identity"); ok {
+		return val.(*graph.NetworkIdentity)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, pendingCountTimeout)
+	defer cancel()
+
+	identity, err := s.graphClient.GetNetworkIdentity(ctx)
+	if err != nil {
+		s.log.WithError(err).Warn("network identity fetch failed")
+		return nil
+	}
+	s.cache.Set("network_identity", identity, networkIdentityTTL)
+
+	return identity
+}
+
+// totalStaked returns the cached total staked amount
+func (s *Server) totalStaked() (types.Amount, error) {
+	if val, ok := s.cache.Get("total_staked"); ok {
+		return val.(types.Amount), nil
+	}
+
+	amount, err := s.db.Accounts.TotalStaked()
+	if err != nil {
+		return amount, err
+	}
+	s.cache.Set("total_staked", amount, totalStakedCacheTTL)
+
+	return amount, nil
 }
 
 // GetLedgers returns a list of all existing ledgers
@@ -4,15 +4,18 @@ import (
 	"time"
 
 	"github.com/figment-networks/mina-indexer/model"
+	"github.com/figment-networks/mina-indexer/model/types"
 )
 
 type HealthResponse struct {
-	Healthy bool `json:"healthy"`
+	Healthy    bool              `json:"healthy"`
+	Components map[string]string `json:"components"`
 }
 
 type StatusResponse struct {
 	AppName         string    `json:"app_name"`
 	AppVersion      string    `json:"app_version"`
+	IndexerVersion  string    `json:"indexer_version"`
 	GitCommit       string    `json:"git_commit"`
 	GoVersion       string    `json:"go_version"`
 	NodeVersion     string    `json:"node_version,omitempty"`
@@ -28,11 +31,48 @@ type HeightResponse struct {
 	Time   time.Time `json:"time"`
 }
 
+// ErrorResponse is rendered for all failed requests
+type ErrorResponse struct {
+	Status  int      `json:"status"`
+	Error   string   `json:"error"`
+	Details []string `json:"details,omitempty"`
+}
+
 type BlockResponse struct {
-	Block        *model.Block        `json:"block"`
-	Creator      *model.Account      `json:"creator"`
-	Transactions []model.Transaction `json:"transactions"`
-	SnarkJobs    []model.SnarkJob    `json:"snark_jobs"`
+	Block        *model.Block         `json:"block"`
+	Creator      *model.Account       `json:"creator"`
+	Transactions []model.Transaction  `json:"transactions"`
+	SnarkJobs    []model.SnarkJob     `json:"snark_jobs"`
+	Snarkers     []model.BlockSnarker `json:"snarkers"`
+}
+
+type BlockEpochDataResponse struct {
+	Epoch                   int    `json:"epoch"`
+	SlotsRemainingInEpoch   int    `json:"slots_remaining_in_epoch"`
+	TotalBlocksInEpochSoFar int    `json:"total_blocks_in_epoch_so_far"`
+	EpochStartHeight        uint64 `json:"epoch_start_height"`
+	EpochEndEstimatedHeight uint64 `json:"epoch_end_estimated_height"`
+}
+
+type EpochResponse struct {
+	Epoch      int `json:"epoch"`
+	BlockCount int `json:"block_count"`
+}
+
+type TransactionResponse struct {
+	*model.Transaction
+	SenderAccount   *model.Account `json:"sender_account"`
+	ReceiverAccount *model.Account `json:"receiver_account"`
+}
+
+type TransactionReceiptResponse struct {
+	Hash          string     `json:"hash"`
+	Status        string     `json:"status"`
+	BlockHash     string     `json:"block_hash,omitempty"`
+	BlockHeight   uint64     `json:"block_height,omitempty"`
+	BlockTime     *time.Time `json:"block_time,omitempty"`
+	Index         *int       `json:"index,omitempty"`
+	Confirmations uint64     `json:"confirmations"`
 }
 
 type ValidatorResponse struct {
@@ -44,6 +84,59 @@ type ValidatorResponse struct {
 	StatsDaily  []model.ValidatorStat `json:"stats_daily"`
 }
 
+type AccountResponse struct {
+	*model.Account
+	TotalSent       types.Amount    `json:"total_sent"`
+	IsValidator     bool            `json:"is_validator"`
+	IsSnarker       bool            `json:"is_snarker"`
+	DelegateAccount *AccountSummary `json:"delegate_account,omitempty"`
+}
+
+type AccountSummary struct {
+	PublicKey string       `json:"public_key"`
+	Balance   types.Amount `json:"balance"`
+	Stake     types.Amount `json:"stake"`
+}
+
+type VotingPowerResponse struct {
+	Validator          string           `json:"validator"`
+	Epoch              int              `json:"epoch"`
+	Stake              types.Amount     `json:"stake"`
+	TotalStake         types.Amount     `json:"total_stake"`
+	VotingPowerPercent types.Percentage `json:"voting_power_percent"`
+}
+
+type ValidatorScheduleResponse struct {
+	Epoch          int     `json:"epoch"`
+	StakeWeight    float64 `json:"stake_weight"`
+	ExpectedBlocks float64 `json:"expected_blocks"`
+	CILower        float64 `json:"ci_lower"`
+	CIUpper        float64 `json:"ci_upper"`
+}
+
+type AccountsBatchRequest struct {
+	PublicKeys []string `json:"public_keys" binding:"required"`
+}
+
+type AccountsBatchResponse struct {
+	Accounts map[string]model.Account `json:"accounts"`
+	Missing  []string                 `json:"missing"`
+}
+
+type NetworkStatsResponse struct {
+	Height              uint64    `json:"height"`
+	Time                time.Time `json:"time"`
+	MempoolDepth        int       `json:"mempool_depth"`
+	MempoolPendingCount int       `json:"mempool_pending_count"`
+	TotalStaked         string    `json:"total_staked_mina"`
+}
+
+type NetworkSummaryResponse struct {
+	*model.NetworkSummary
+	ChainID          string `json:"chain_id"`
+	GenesisTimestamp string `json:"genesis_timestamp"`
+}
+
 type LedgerRequest struct {
 	Epoch *int `form:"epoch"`
 }
@@ -4,8 +4,10 @@ import (
 	"time"
 
 	"github.com/figment-networks/indexing-engine/store/bulk"
+	"github.com/lib/pq"
 
 	"github.com/figment-networks/mina-indexer/model"
+	"github.com/figment-networks/mina-indexer/model/types"
 	"github.com/figment-networks/mina-indexer/store/queries"
 )
 
@@ -16,14 +18,14 @@ type AccountsStore struct {
 
 func (s AccountsStore) Count() (int, error) {
 	var n int
-	err := s.db.Table("accounts").Count(&n).Error
+	err := s.readDB.Table("accounts").Count(&n).Error
 	return n, err
 }
 
 // FindBy returns an account for a matching attribute
 func (s AccountsStore) FindBy(key string, value interface{}) (*model.Account, error) {
 	result := &model.Account{}
-	err := findBy(s.db, result, key, value)
+	err := findBy(s.readDB, result, key, value)
 	return result, checkErr(err)
 }
 
@@ -37,10 +39,22 @@ func (s AccountsStore) FindByPublicKey(key string) (*model.Account, error) {
 	return s.FindBy("public_key", key)
 }
 
+// FindByPublicKeys returns accounts for the given public keys
+func (s AccountsStore) FindByPublicKeys(keys []string) ([]model.Account, error) {
+	result := []model.Account{}
+
+	err := s.readDB.
+		Where("public_key = ANY(?)", pq.Array(keys)).
+		Find(&result).
+		Error
+
+	return result, checkErr(err)
+}
+
 // AllByDelegator returns all accounts delegated to another account
 func (s AccountsStore) AllByDelegator(account string) ([]model.Account, error) {
 	result := []model.Account{}
-	err := s.db.
+	err := s.readDB.
 		Where("delegate = ?", account).
 		Find(&result).
 		Error
@@ -51,7 +65,7 @@ func (s AccountsStore) AllByDelegator(account string) ([]model.Account, error) {
 func (s AccountsStore) ByHeight(height int64) ([]model.Account, error) {
 	result := []model.Account{}
 
-	err := s.db.
+	err := s.readDB.
 		Where("start_height <= ?", height).
 		Order("id DESC").
 		Find(&result).
@@ -64,7 +78,7 @@ func (s AccountsStore) ByHeight(height int64) ([]model.Account, error) {
 func (s AccountsStore) All() ([]model.Account, error) {
 	result := []model.Account{}
 
-	err := s.db.
+	err := s.readDB.
 		Order("id ASC").
 		Find(&result).
 		Error
@@ -72,6 +86,70 @@ func (s AccountsStore) All() ([]model.Account, error) {
 	return result, checkErr(err)
 }
 
+// CreatedSince returns accounts first seen on chain after the given time, newest first
+func (s AccountsStore) CreatedSince(since time.Time, limit, offset int) ([]model.Account, error) {
+	result := []model.Account{}
+
+	err := s.readDB.
+		Where("start_time >= ?", since).
+		Order("start_time DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&result).
+		Error
+
+	return result, checkErr(err)
+}
+
+// TokenBalances returns the custom token balances of the account
+func (s AccountsStore) TokenBalances(publicKey string) ([]model.TokenBalance, error) {
+	result := []model.TokenBalance{}
+
+	err := s.readDB.
+		Where("public_key = ?", publicKey).
+		Order("token_id ASC").
+		Find(&result).
+		Error
+
+	return result, checkErr(err)
+}
+
+// ImportTokenBalances creates or updates the account token balances
+func (s AccountsStore) ImportTokenBalances(records []model.TokenBalance) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+
+	return bulk.Import(s.db, queries.AccountsImportTokens, len(records), func(idx int) bulk.Row {
+		r := records[idx]
+
+		return bulk.Row{
+			r.PublicKey,
+			r.TokenID,
+			r.TokenSymbol,
+			r.Balance,
+			now,
+			now,
+		}
+	})
+}
+
+// TotalStaked returns the total balance of all accounts delegated to a validator
+func (s AccountsStore) TotalStaked() (types.Amount, error) {
+	result := types.NewInt64Amount(0)
+
+	err := s.readDB.
+		Table("accounts").
+		Select("COALESCE(SUM(balance), 0)").
+		Where("delegate IS NOT NULL").
+		Row().
+		Scan(&result)
+
+	return result, err
+}
+
 func (s AccountsStore) UpdateStaking() error {
 	return s.db.Exec(queries.AccountsUpdateStaking).Error
 }
@@ -106,6 +184,11 @@ func (s AccountsStore) Import(records []model.Account) error {
 				acc.StartTime,
 				acc.LastHeight,
 				acc.LastTime,
+				acc.TimingInitialMinimumBalance,
+				acc.TimingCliffTime,
+				acc.TimingCliffAmount,
+				acc.TimingVestingPeriod,
+				acc.TimingVestingIncrement,
 				now,
 				now,
 			}
@@ -3,18 +3,24 @@ package store
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/jinzhu/gorm"
 )
 
 var (
 	ErrNotFound = errors.New("record not found")
+
+	likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
 )
 
-// baseStore implements generic store operations
+// baseStore implements generic store operations.
+// Read-only queries go through readDB, which points to the primary database
+// unless a read replica is configured.
 type baseStore struct {
-	db    *gorm.DB
-	model interface{}
+	db     *gorm.DB
+	readDB *gorm.DB
+	model  interface{}
 }
 
 // Create creates a new record. Must pass a pointer.
@@ -39,8 +45,8 @@ func (s baseStore) DeleteByHeight(height int64) error {
 	return s.db.Delete(s.model, "height = ?", height).Error
 }
 
-func scoped(conn *gorm.DB, m interface{}) baseStore {
-	return baseStore{conn, m}
+func scoped(conn *gorm.DB, readConn *gorm.DB, m interface{}) baseStore {
+	return baseStore{conn, readConn, m}
 }
 
 func isNotFound(err error) bool {
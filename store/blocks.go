@@ -2,6 +2,9 @@ package store
 
 import (
 	"fmt"
+	"strings"
+
+	"github.com/jinzhu/gorm"
 
 	"github.com/figment-networks/indexing-engine/store/jsonquery"
 	"github.com/figment-networks/mina-indexer/model"
@@ -16,7 +19,7 @@ type BlocksStore struct {
 // FindBy returns a block for a matching attribute
 func (s BlocksStore) FindBy(key string, value interface{}) (*model.Block, error) {
 	result := &model.Block{}
-	err := findBy(s.db, result, key, value)
+	err := findBy(s.readDB, result, key, value)
 	return result, checkErr(err)
 }
 
@@ -34,33 +37,156 @@ func (s BlocksStore) FindByHash(hash string) (*model.Block, error) {
 func (s BlocksStore) FindByHeight(height uint64) (*model.Block, error) {
 	result := model.Block{}
 
-	scope := s.db.Limit(1)
+	scope := s.readDB.Limit(1)
 	scope = scope.Where("height = ? AND canonical = ?", height, true)
 	err := scope.Find(&result).Error
 	return &result, checkErr(err)
 }
 
+// SearchFull returns blocks with creator or hashes matching the text, most relevant first
+func (s BlocksStore) SearchFull(text string, limit int) ([]model.Block, error) {
+	result := []model.Block{}
+
+	query := searchQuery(text)
+	if query == "" {
+		return result, nil
+	}
+
+	err := s.readDB.Raw(queries.BlocksSearchFull, query, limit).Scan(&result).Error
+	return result, checkErr(err)
+}
+
+// MissingHeights returns the heights within the range without a canonical block
+func (s BlocksStore) MissingHeights(from, to uint64) ([]uint64, error) {
+	rows, err := s.readDB.Raw(queries.BlocksMissingHeights, from, to).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []uint64{}
+	for rows.Next() {
+		var height uint64
+		if err := rows.Scan(&height); err != nil {
+			return nil, err
+		}
+		result = append(result, height)
+	}
+
+	return result, rows.Err()
+}
+
 // Recent returns the most recent block
 func (s BlocksStore) Recent() (*model.Block, error) {
-	block := &model.Block{}
-	err := s.db.Where("canonical = ?", true).Order("height DESC").Limit(1).Take(block).Error
-	return block, checkErr(err)
+	blocks, err := s.RecentN(1)
+	if err != nil {
+		return nil, err
+	}
+	if len(blocks) == 0 {
+		return nil, ErrNotFound
+	}
+	return &blocks[0], nil
+}
+
+// RecentN returns up to n most recent canonical blocks ordered by height descending
+func (s BlocksStore) RecentN(n int) ([]model.Block, error) {
+	result := []model.Block{}
+	err := s.readDB.Where("canonical = ?", true).Order("height DESC").Limit(n).Find(&result).Error
+	return result, checkErr(err)
+}
+
+// TipHeight returns the height of the most recent canonical block
+func (s BlocksStore) TipHeight() (uint64, error) {
+	var result struct{ Height uint64 }
+	err := s.readDB.
+		Model(&model.Block{}).
+		Select("COALESCE(MAX(height), 0) AS height").
+		Where("canonical = ?", true).
+		Scan(&result).
+		Error
+	return result.Height, checkErr(err)
 }
 
 // LastBlock returns the last block
 func (s BlocksStore) LastBlock() (*model.Block, error) {
 	block := &model.Block{}
-	err := s.db.Order("height DESC").Limit(1).Take(block).Error
+	err := s.readDB.Order("height DESC").Limit(1).Take(block).Error
 	return block, checkErr(err)
 }
 
-// Search returns blocks that match search filters
-func (s BlocksStore) Search(search *BlockSearch) ([]model.Block, error) {
+// CountInEpoch returns the number of canonical blocks in the epoch up to the height
+func (s BlocksStore) CountInEpoch(epoch int, maxHeight uint64) (int, error) {
+	var count int
+
+	err := s.readDB.
+		Model(&model.Block{}).
+		Where("epoch = ? AND height <= ? AND canonical = ?", epoch, maxHeight, true).
+		Count(&count).
+		Error
+
+	return count, err
+}
+
+// ByEpoch returns a page of canonical blocks of the epoch ordered by height
+func (s BlocksStore) ByEpoch(epoch string, limit, offset int) ([]model.Block, error) {
 	result := []model.Block{}
 
-	scope := s.db.
+	err := s.epochScope(epoch).
+		Order("height ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&result).
+		Error
+
+	return result, checkErr(err)
+}
+
+// CountByEpoch returns the number of canonical blocks in the epoch
+func (s BlocksStore) CountByEpoch(epoch string) (int, error) {
+	var count int
+	err := s.epochScope(epoch).Count(&count).Error
+	return count, err
+}
+
+func (s BlocksStore) epochScope(epoch string) *gorm.DB {
+	return s.readDB.
+		Model(&model.Block{}).
+		Where("epoch = ? AND canonical = ?", epoch, true)
+}
+
+// FirstBlock returns the oldest canonical block
+func (s BlocksStore) FirstBlock() (*model.Block, error) {
+	block := &model.Block{}
+	err := s.readDB.Where("canonical = ?", true).Order("height ASC").Limit(1).Take(block).Error
+	return block, checkErr(err)
+}
+
+// Search returns blocks that match search filters and the total count of matches
+func (s BlocksStore) Search(search *BlockSearch) ([]model.Block, int64, error) {
+	scope := s.filter(search)
+
+	count, err := cachedCount(scope)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := []model.Block{}
+	err = scope.
 		Order(fmt.Sprintf("%s %s", search.Sort, search.Order)).
-		Limit(search.Limit)
+		Limit(search.Limit).
+		Find(&result).
+		Error
+
+	return result, count, err
+}
+
+// filter returns a scope limited to the search filters
+func (s BlocksStore) filter(search *BlockSearch) *gorm.DB {
+	scope := s.readDB.Model(&model.Block{})
+
+	if !search.IncludeOrphaned {
+		scope = scope.Where("orphaned = ?", false)
+	}
 
 	if search.MinHeight > 0 {
 		scope = scope.Where("height >= ?", search.MinHeight)
@@ -70,21 +196,52 @@ func (s BlocksStore) Search(search *BlockSearch) ([]model.Block, error) {
 		scope = scope.Where("height <= ?", search.MaxHeight)
 	}
 
+	if search.Epoch != nil {
+		scope = scope.Where("epoch = ?", *search.Epoch)
+	}
+
+	if search.Slot != nil {
+		scope = scope.Where("slot = ?", search.GlobalSlot())
+	}
+
 	if search.Creator != "" {
 		scope = scope.Where("creator = ?", search.Creator)
 	}
 
-	return result, scope.Find(&result).Error
+	if search.HasTimeRange() {
+		scope = scope.Where("time BETWEEN ? AND ?", *search.StartTime, *search.EndTime)
+	}
+
+	if search.HasSnarkJobs != nil {
+		if *search.HasSnarkJobs {
+			scope = scope.Where("snark_jobs_count > 0")
+		} else {
+			scope = scope.Where("snark_jobs_count = 0")
+		}
+	}
+
+	return scope
 }
 
-// AvgTimes returns recent blocks averages
+// AvgTimes returns recent blocks averages and block time percentiles
 func (s BlocksStore) AvgTimes(limit int64) ([]byte, error) {
-	return jsonquery.MustObject(s.db, queries.BlocksTimes, limit)
+	return jsonquery.MustObject(s.readDB, queries.BlocksTimes, limit)
+}
+
+// BlockTimesSeries returns the average block times of canonical blocks within the window,
+// e.g. "30d", grouped by hour or day bucket
+func (s BlocksStore) BlockTimesSeries(window, bucket string) ([]model.BlockTimePoint, error) {
+	result := []model.BlockTimePoint{}
+
+	interval := strings.TrimSuffix(window, "d") + " days"
+	err := s.readDB.Raw(queries.BlocksTimesSeries, bucket, interval).Scan(&result).Error
+
+	return result, checkErr(err)
 }
 
 // Stats returns block stats for a given interval
 func (s BlocksStore) Stats(period uint, interval string) ([]byte, error) {
-	return jsonquery.MustArray(s.db, queries.BlocksStats, period, interval)
+	return jsonquery.MustArray(s.readDB, queries.BlocksStats, period, interval)
 }
 
 // MarkBlocksOrphan updates all blocks as non canonical at a height
@@ -97,11 +254,20 @@ func (s BlocksStore) MarkBlockCanonical(hash string) error {
 	return s.db.Exec(queries.MarkBlockCanonical, hash).Error
 }
 
+// MarkOrphaned marks the block as orphaned
+func (s BlocksStore) MarkOrphaned(hash string) error {
+	return s.db.
+		Model(&model.Block{}).
+		Where("hash = ?", hash).
+		Updates(map[string]interface{}{"orphaned": true, "canonical": false}).
+		Error
+}
+
 // FindUnsafeBlocks returns the last indexed unsafe blocks that may be orphaned
 func (s BlocksStore) FindUnsafeBlocks(startingHeight uint64) ([]model.Block, error) {
 	result := []model.Block{}
 
-	scope := s.db.
+	scope := s.readDB.
 		Where("height >= ?", startingHeight).
 		Order("height asc")
 
@@ -2,26 +2,42 @@ package store
 
 import (
 	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/figment-networks/mina-indexer/model"
 )
 
+const maxBlocksTimeRange = 7 * 24 * time.Hour
+
 // BlockSearch contains a block search params
 type BlockSearch struct {
-	Creator   string `form:"creator"`
-	MinHeight uint   `form:"min_height"`
-	MaxHeight uint   `form:"max_height"`
-	Sort      string `form:"sort"`
-	Order     string `form:"order"`
-	Limit     uint   `form:"limit"`
+	Creator         string     `form:"creator"`
+	MinHeight       uint       `form:"min_height"`
+	MaxHeight       uint       `form:"max_height"`
+	Epoch           *int       `form:"epoch"`
+	Slot            *int       `form:"slot"`
+	HasSnarkJobs    *bool      `form:"has_snark_jobs"`
+	IncludeOrphaned bool       `form:"include_orphaned"`
+	StartTime       *time.Time `form:"start_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	EndTime         *time.Time `form:"end_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	Sort            string     `form:"sort"`
+	Order           string     `form:"order"`
+	Limit           uint       `form:"limit"`
 }
 
 // Validate performs validation on search parameters
 func (search *BlockSearch) Validate() error {
+	errs := ValidationErrors{}
+
 	switch search.Sort {
 	case "height":
 	case "":
 		search.Sort = "height"
 	default:
-		return errors.New("invalid sort field")
+		errs = append(errs, "invalid sort field")
 	}
 
 	switch search.Order {
@@ -29,15 +45,75 @@ func (search *BlockSearch) Validate() error {
 		search.Order = "desc"
 	case "asc", "desc":
 	default:
-		return errors.New("invalid sort order")
+		errs = append(errs, "invalid sort order")
+	}
+
+	if (search.StartTime == nil) != (search.EndTime == nil) {
+		errs = append(errs, "both start_time and end_time are required")
+	}
+	if search.HasTimeRange() {
+		if err := ValidateTimeRange(*search.StartTime, *search.EndTime); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if search.Epoch != nil && *search.Epoch < 0 {
+		errs = append(errs, "epoch must not be negative")
+	}
+	if search.Slot != nil {
+		if search.Epoch == nil {
+			errs = append(errs, "slot requires epoch")
+		}
+		if *search.Slot < 0 || *search.Slot >= model.SlotsPerEpoch {
+			errs = append(errs, fmt.Sprintf("slot must be between 0 and %d", model.SlotsPerEpoch-1))
+		}
 	}
 
 	if search.Limit == 0 {
 		search.Limit = 100
 	}
 	if search.Limit > 100 {
-		return errors.New("max limit is 100")
+		errs = append(errs, "max limit is 100")
 	}
 
+	return errs.errorOrNil()
+}
+
+// GlobalSlot returns the global slot of the epoch relative slot filter
+func (search *BlockSearch) GlobalSlot() int {
+	return *search.Epoch*model.SlotsPerEpoch + *search.Slot
+}
+
+// HasTimeRange returns true if search is limited to a time range
+func (search *BlockSearch) HasTimeRange() bool {
+	return search.StartTime != nil && search.EndTime != nil
+}
+
+// ValidateTimeRange returns an error if the blocks time range is invalid or too wide
+func ValidateTimeRange(from, to time.Time) error {
+	if to.Before(from) {
+		return errors.New("end_time must be after start_time")
+	}
+	if to.Sub(from) > maxBlocksTimeRange {
+		return errors.New("time range must not exceed 7 days")
+	}
 	return nil
 }
+
+// searchQuery returns a prefix matching text search query for the input words
+func searchQuery(text string) string {
+	terms := []string{}
+	for _, word := range strings.Fields(text) {
+		word = strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		}, word)
+
+		if word != "" {
+			terms = append(terms, word+":*")
+		}
+	}
+	return strings.Join(terms, " & ")
+}
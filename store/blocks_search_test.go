@@ -0,0 +1,41 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/figment-networks/mina-indexer/model"
+)
+
+func TestBlockSearchGlobalSlot(t *testing.T) {
+	examples := []struct {
+		epoch    int
+		slot     int
+		expected int
+	}{
+		{epoch: 0, slot: 0, expected: 0},
+		{epoch: 0, slot: 7139, expected: 7139},
+		{epoch: 1, slot: 0, expected: model.SlotsPerEpoch},
+		{epoch: 3, slot: 25, expected: 3*model.SlotsPerEpoch + 25},
+	}
+
+	for _, ex := range examples {
+		search := &BlockSearch{Epoch: &ex.epoch, Slot: &ex.slot}
+
+		assert.NoError(t, search.Validate())
+		assert.Equal(t, ex.expected, search.GlobalSlot())
+	}
+}
+
+func TestBlockSearchSlotValidation(t *testing.T) {
+	epoch := 1
+	slot := model.SlotsPerEpoch
+
+	search := &BlockSearch{Epoch: &epoch, Slot: &slot}
+	assert.EqualError(t, search.Validate(), "slot must be between 0 and 7139")
+
+	slot = 10
+	search = &BlockSearch{Slot: &slot}
+	assert.EqualError(t, search.Validate(), "slot requires epoch")
+}
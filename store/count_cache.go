@@ -0,0 +1,58 @@
+package store
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// countCacheTTL is the duration a search result count is reused for
+const countCacheTTL = 30 * time.Second
+
+var searchCounts = &countCache{items: map[string]countCacheItem{}}
+
+type countCacheItem struct {
+	count     int64
+	expiresAt time.Time
+}
+
+// countCache keeps the recent search counts to avoid counting rows on every page request
+type countCache struct {
+	items map[string]countCacheItem
+	lock  sync.Mutex
+}
+
+// cachedCount returns the number of records matching the scope, reusing a recent count
+func cachedCount(scope *gorm.DB) (int64, error) {
+	expr := scope.QueryExpr()
+	key := fmt.Sprintf("%v", *expr)
+	now := time.Now()
+
+	searchCounts.lock.Lock()
+	item, ok := searchCounts.items[key]
+	searchCounts.lock.Unlock()
+
+	if ok && now.Before(item.expiresAt) {
+		return item.count, nil
+	}
+
+	var count int64
+	if err := scope.Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	searchCounts.lock.Lock()
+	defer searchCounts.lock.Unlock()
+
+	// Drop the expired counts so the cache does not grow with every distinct search
+	for k, v := range searchCounts.items {
+		if now.After(v.expiresAt) {
+			delete(searchCounts.items, k)
+		}
+	}
+	searchCounts.items[key] = countCacheItem{count: count, expiresAt: now.Add(countCacheTTL)}
+
+	return count, nil
+}
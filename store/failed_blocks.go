@@ -0,0 +1,36 @@
+package store
+
+import (
+	"github.com/figment-networks/mina-indexer/model"
+	"github.com/figment-networks/mina-indexer/store/queries"
+)
+
+// FailedBlocksStore handles operations on blocks that failed to import
+type FailedBlocksStore struct {
+	baseStore
+}
+
+// Record stores the import failure of a block, repeated failures increase the attempts count
+func (s FailedBlocksStore) Record(height uint64, hash string, err error) error {
+	return s.db.Exec(queries.FailedBlocksRecord, height, hash, err.Error()).Error
+}
+
+// FindByHeight returns the failed block of the height. The primary database is used
+// since the record is read right before it's retried and resolved.
+func (s FailedBlocksStore) FindByHeight(height uint64) (*model.FailedBlock, error) {
+	result := &model.FailedBlock{}
+	err := findBy(s.db, result, "height", height)
+	return result, checkErr(err)
+}
+
+// All returns all failed blocks ordered by height
+func (s FailedBlocksStore) All() ([]model.FailedBlock, error) {
+	result := []model.FailedBlock{}
+	err := s.readDB.Order("height ASC").Find(&result).Error
+	return result, checkErr(err)
+}
+
+// Resolve removes the failed block of the height
+func (s FailedBlocksStore) Resolve(height uint64) error {
+	return s.db.Delete(&model.FailedBlock{}, "height = ?", height).Error
+}
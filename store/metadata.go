@@ -0,0 +1,23 @@
+package store
+
+import (
+	"github.com/figment-networks/mina-indexer/model"
+	"github.com/figment-networks/mina-indexer/store/queries"
+)
+
+// MetadataStore handles operations on indexer metadata
+type MetadataStore struct {
+	baseStore
+}
+
+// Get returns the value stored for the key
+func (s MetadataStore) Get(key string) (string, error) {
+	result := &model.Metadata{}
+	err := findBy(s.readDB, result, "key", key)
+	return result.Value, checkErr(err)
+}
+
+// Set stores the value for the key
+func (s MetadataStore) Set(key, value string) error {
+	return s.db.Exec(queries.MetadataSet, key, value).Error
+}
@@ -0,0 +1,64 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose"
+	log "github.com/sirupsen/logrus"
+)
+
+// migrationLockID is the advisory lock key held while migrations are applied
+const migrationLockID = 7340032
+
+// Migrate applies the pending migrations from the directory in version order.
+// An advisory lock keeps the processes starting at the same time from applying
+// the same migration twice.
+func Migrate(db *sql.DB, migrationsDir string) error {
+	ctx := context.Background()
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return err
+	}
+	defer func() {
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", migrationLockID); err != nil {
+			log.WithError(err).Error("migration lock release failed")
+		}
+	}()
+
+	current, err := goose.GetDBVersion(db)
+	if err != nil {
+		return err
+	}
+
+	if err := goose.Up(db, migrationsDir); err != nil {
+		return err
+	}
+
+	version, err := goose.GetDBVersion(db)
+	if err != nil {
+		return err
+	}
+
+	applied := 0
+	if version > current {
+		migrations, err := goose.CollectMigrations(migrationsDir, current, version)
+		if err != nil {
+			return err
+		}
+		applied = len(migrations)
+	}
+
+	log.
+		WithField("applied", applied).
+		WithField("version", version).
+		Info("database schema is up to date")
+
+	return nil
+}
@@ -0,0 +1,8 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+ALTER TYPE CHAIN_TX_TYPE ADD VALUE IF NOT EXISTS 'zkapp';
+
+ALTER TABLE transactions ADD COLUMN zkapp_body JSONB;
+
+-- +goose Down
+ALTER TABLE transactions DROP COLUMN zkapp_body;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE accounts ALTER COLUMN nonce SET DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE accounts ALTER COLUMN nonce DROP DEFAULT;
@@ -0,0 +1,8 @@
+-- +goose Up
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_validators_identity_name
+  ON validators USING GIN (identity_name gin_trgm_ops);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_validators_identity_name;
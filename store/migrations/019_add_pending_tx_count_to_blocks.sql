@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE blocks ADD COLUMN pending_tx_count INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE blocks DROP COLUMN pending_tx_count;
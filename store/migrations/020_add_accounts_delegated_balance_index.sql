@@ -0,0 +1,7 @@
+-- +goose Up
+CREATE INDEX idx_accounts_delegated_balance
+  ON accounts(balance)
+  WHERE delegate IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_accounts_delegated_balance;
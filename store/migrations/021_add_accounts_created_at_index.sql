@@ -0,0 +1,5 @@
+-- +goose Up
+CREATE INDEX idx_accounts_created_at ON accounts(created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_accounts_created_at;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE blocks ADD COLUMN orphaned BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+ALTER TABLE blocks DROP COLUMN orphaned;
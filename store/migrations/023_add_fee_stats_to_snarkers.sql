@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE snarkers ADD COLUMN fee_total DECIMAL(65, 0) NOT NULL DEFAULT 0;
+ALTER TABLE snarkers ADD COLUMN avg_fee DECIMAL(65, 0) NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE snarkers DROP COLUMN fee_total;
+ALTER TABLE snarkers DROP COLUMN avg_fee;
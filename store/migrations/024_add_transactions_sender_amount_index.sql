@@ -0,0 +1,5 @@
+-- +goose Up
+CREATE INDEX idx_transactions_sender_amount ON transactions(sender, amount);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_transactions_sender_amount;
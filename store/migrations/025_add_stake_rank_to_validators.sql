@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE validators ADD COLUMN stake_rank INTEGER;
+
+-- +goose Down
+ALTER TABLE validators DROP COLUMN stake_rank;
@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS account_tokens (
+  id           SERIAL NOT NULL,
+  public_key   TEXT NOT NULL,
+  token_id     TEXT NOT NULL,
+  token_symbol TEXT,
+  balance      DECIMAL(65, 0) NOT NULL,
+  created_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+
+  PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX idx_account_tokens_public_key_token
+  ON account_tokens(public_key, token_id);
+
+-- +goose Down
+DROP TABLE account_tokens;
@@ -0,0 +1,6 @@
+-- +goose Up
+CREATE INDEX idx_snark_jobs_block_hash_prover
+  ON snark_jobs(block_hash, prover);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_snark_jobs_block_hash_prover;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE chain_stats ADD COLUMN min_snark_fee CHAIN_CURRENCY DEFAULT 0;
+ALTER TABLE chain_stats ADD COLUMN max_snark_fee CHAIN_CURRENCY DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE chain_stats DROP COLUMN min_snark_fee;
+ALTER TABLE chain_stats DROP COLUMN max_snark_fee;
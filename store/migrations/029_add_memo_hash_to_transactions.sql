@@ -0,0 +1,8 @@
+-- +goose Up
+ALTER TABLE transactions ADD COLUMN memo_hash TEXT;
+UPDATE transactions SET memo_hash = ENCODE(SHA256(CONVERT_TO(memo, 'UTF8')), 'hex') WHERE memo IS NOT NULL;
+CREATE INDEX idx_transactions_memo_hash ON transactions(memo_hash);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_transactions_memo_hash;
+ALTER TABLE transactions DROP COLUMN memo_hash;
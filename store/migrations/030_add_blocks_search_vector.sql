@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE blocks ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+  TO_TSVECTOR('simple', COALESCE(creator, '') || ' ' || COALESCE(hash, '') || ' ' || COALESCE(parent_hash, ''))
+) STORED;
+CREATE INDEX idx_blocks_search_vector ON blocks USING GIN(search_vector);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_blocks_search_vector;
+ALTER TABLE blocks DROP COLUMN search_vector;
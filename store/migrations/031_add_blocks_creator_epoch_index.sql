@@ -0,0 +1,5 @@
+-- +goose Up
+CREATE INDEX idx_blocks_creator_epoch ON blocks(creator, epoch);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_blocks_creator_epoch;
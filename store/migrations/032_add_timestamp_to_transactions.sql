@@ -0,0 +1,6 @@
+-- +goose Up
+ALTER TABLE transactions ADD COLUMN timestamp CHAIN_TIME;
+UPDATE transactions SET timestamp = time;
+
+-- +goose Down
+ALTER TABLE transactions DROP COLUMN timestamp;
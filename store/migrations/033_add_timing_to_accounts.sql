@@ -0,0 +1,15 @@
+-- +goose Up
+ALTER TABLE accounts
+  ADD COLUMN timing_initial_minimum_balance CHAIN_CURRENCY,
+  ADD COLUMN timing_cliff_time              INTEGER,
+  ADD COLUMN timing_cliff_amount            CHAIN_CURRENCY,
+  ADD COLUMN timing_vesting_period          INTEGER,
+  ADD COLUMN timing_vesting_increment       CHAIN_CURRENCY;
+
+-- +goose Down
+ALTER TABLE accounts
+  DROP COLUMN timing_initial_minimum_balance,
+  DROP COLUMN timing_cliff_time,
+  DROP COLUMN timing_cliff_amount,
+  DROP COLUMN timing_vesting_period,
+  DROP COLUMN timing_vesting_increment;
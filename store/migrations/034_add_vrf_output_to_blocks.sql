@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE blocks ADD COLUMN block_producer_vrf_output TEXT;
+
+-- +goose Down
+ALTER TABLE blocks DROP COLUMN block_producer_vrf_output;
@@ -0,0 +1,5 @@
+-- +goose Up
+CREATE INDEX idx_transactions_lower_hash ON transactions(LOWER(hash));
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_transactions_lower_hash;
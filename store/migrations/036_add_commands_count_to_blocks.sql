@@ -0,0 +1,23 @@
+-- +goose Up
+ALTER TABLE blocks
+  ADD COLUMN user_commands_count INTEGER NOT NULL DEFAULT 0,
+  ADD COLUMN internal_commands_count INTEGER NOT NULL DEFAULT 0;
+
+UPDATE blocks
+SET
+  user_commands_count = counts.user_commands_count,
+  internal_commands_count = counts.internal_commands_count
+FROM (
+  SELECT
+    block_hash,
+    COUNT(1) FILTER (WHERE type IN ('payment', 'delegation', 'zkapp')) AS user_commands_count,
+    COUNT(1) FILTER (WHERE type NOT IN ('payment', 'delegation', 'zkapp')) AS internal_commands_count
+  FROM transactions
+  GROUP BY block_hash
+) counts
+WHERE counts.block_hash = blocks.hash;
+
+-- +goose Down
+ALTER TABLE blocks
+  DROP COLUMN user_commands_count,
+  DROP COLUMN internal_commands_count;
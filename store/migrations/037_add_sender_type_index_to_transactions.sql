@@ -0,0 +1,5 @@
+-- +goose Up
+CREATE INDEX idx_transactions_sender_type_height ON transactions(sender, type, block_height);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_transactions_sender_type_height;
@@ -0,0 +1,5 @@
+-- +goose Up
+CREATE INDEX idx_blocks_epoch_height ON blocks(epoch, height);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_blocks_epoch_height;
@@ -0,0 +1,22 @@
+-- +goose Up
+CREATE TABLE daily_tx_volume (
+  date         DATE NOT NULL PRIMARY KEY,
+  tx_count     INTEGER NOT NULL,
+  total_amount CHAIN_CURRENCY NOT NULL,
+  total_fees   CHAIN_CURRENCY NOT NULL,
+  updated_at   TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+INSERT INTO daily_tx_volume (date, tx_count, total_amount, total_fees, updated_at)
+SELECT
+  (time AT TIME ZONE 'UTC')::DATE,
+  COUNT(1),
+  COALESCE(SUM(amount), 0),
+  COALESCE(SUM(fee), 0),
+  NOW()
+FROM transactions
+WHERE canonical = TRUE
+GROUP BY 1;
+
+-- +goose Down
+DROP TABLE IF EXISTS daily_tx_volume;
@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE failed_blocks (
+  height     CHAIN_HEIGHT PRIMARY KEY,
+  hash       TEXT NOT NULL,
+  error      TEXT NOT NULL,
+  attempts   INTEGER NOT NULL DEFAULT 1,
+  created_at CHAIN_TIME,
+  updated_at CHAIN_TIME
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS failed_blocks;
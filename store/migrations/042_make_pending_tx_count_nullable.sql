@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE blocks ALTER COLUMN pending_tx_count DROP NOT NULL;
+ALTER TABLE blocks ALTER COLUMN pending_tx_count DROP DEFAULT;
+UPDATE blocks SET pending_tx_count = NULL WHERE pending_tx_count = 0;
+
+-- +goose Down
+UPDATE blocks SET pending_tx_count = 0 WHERE pending_tx_count IS NULL;
+ALTER TABLE blocks ALTER COLUMN pending_tx_count SET DEFAULT 0;
+ALTER TABLE blocks ALTER COLUMN pending_tx_count SET NOT NULL;
@@ -0,0 +1,7 @@
+-- +goose Up
+DROP INDEX IF EXISTS idx_accounts_created_at;
+CREATE INDEX idx_accounts_start_time ON accounts(start_time);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_accounts_start_time;
+CREATE INDEX idx_accounts_created_at ON accounts(created_at);
@@ -0,0 +1,48 @@
+package store
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	poolCheckInterval = 30 * time.Second
+
+	// poolSaturationRatio is the share of open connections considered as saturated pool
+	poolSaturationRatio = 0.9
+)
+
+// monitorPool periodically checks the connection pool stats until the store is closed
+func (s *Store) monitorPool(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var lastWaitCount int64
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			stats := s.db.DB().Stats()
+
+			fields := log.Fields{
+				"open":          stats.OpenConnections,
+				"max_open":      stats.MaxOpenConnections,
+				"in_use":        stats.InUse,
+				"idle":          stats.Idle,
+				"wait_count":    stats.WaitCount,
+				"wait_duration": stats.WaitDuration,
+			}
+
+			if stats.MaxOpenConnections > 0 && float64(stats.OpenConnections) >= float64(stats.MaxOpenConnections)*poolSaturationRatio {
+				log.WithFields(fields).Warn("database connection pool is almost saturated")
+			}
+			if stats.WaitCount > lastWaitCount {
+				log.WithFields(fields).Warn("database queries waited for a free connection")
+			}
+			lastWaitCount = stats.WaitCount
+		}
+	}
+}
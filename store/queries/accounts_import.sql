@@ -8,6 +8,11 @@ INSERT INTO accounts (
   start_time,
   last_height,
   last_time,
+  timing_initial_minimum_balance,
+  timing_cliff_time,
+  timing_cliff_amount,
+  timing_vesting_period,
+  timing_vesting_increment,
   created_at,
   updated_at
 )
@@ -20,4 +25,9 @@ SET
   nonce           = excluded.nonce,
   last_height     = excluded.last_height,
   last_time       = excluded.last_time,
+  timing_initial_minimum_balance = COALESCE(excluded.timing_initial_minimum_balance, accounts.timing_initial_minimum_balance),
+  timing_cliff_time              = COALESCE(excluded.timing_cliff_time, accounts.timing_cliff_time),
+  timing_cliff_amount            = COALESCE(excluded.timing_cliff_amount, accounts.timing_cliff_amount),
+  timing_vesting_period          = COALESCE(excluded.timing_vesting_period, accounts.timing_vesting_period),
+  timing_vesting_increment       = COALESCE(excluded.timing_vesting_increment, accounts.timing_vesting_increment),
   updated_at      = excluded.updated_at
\ No newline at end of file
@@ -0,0 +1,14 @@
+INSERT INTO account_tokens (
+  public_key,
+  token_id,
+  token_symbol,
+  balance,
+  created_at,
+  updated_at
+)
+VALUES @values
+ON CONFLICT (public_key, token_id) DO UPDATE
+SET
+  token_symbol = excluded.token_symbol,
+  balance      = excluded.balance,
+  updated_at   = excluded.updated_at
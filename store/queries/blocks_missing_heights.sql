@@ -0,0 +1,11 @@
+SELECT
+  heights.height
+FROM
+  GENERATE_SERIES($1::BIGINT, $2::BIGINT) AS heights(height)
+WHERE
+  NOT EXISTS (
+    SELECT 1 FROM blocks
+    WHERE blocks.height = heights.height AND blocks.canonical = TRUE
+  )
+ORDER BY
+  heights.height ASC
@@ -0,0 +1,12 @@
+SELECT
+  blocks.*
+FROM
+  blocks,
+  TO_TSQUERY('simple', $1) query
+WHERE
+  blocks.search_vector @@ query
+  AND blocks.orphaned = FALSE
+ORDER BY
+  TS_RANK(blocks.search_vector, query) DESC,
+  blocks.height DESC
+LIMIT $2
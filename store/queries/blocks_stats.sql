@@ -17,7 +17,9 @@ SELECT
   total_currency::TEXT total_currency,
   staked_amount::TEXT staked_amount,
   delegations_count,
-  delegations_amount::TEXT delegations_amount
+  delegations_amount::TEXT delegations_amount,
+  min_snark_fee::TEXT min_snark_fee,
+  max_snark_fee::TEXT max_snark_fee
 FROM
   chain_stats
 WHERE
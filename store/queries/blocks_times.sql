@@ -1,14 +1,26 @@
+WITH recent AS (
+  SELECT height, time, canonical FROM blocks
+  ORDER BY height DESC
+  LIMIT ?
+),
+intervals AS (
+  SELECT
+    EXTRACT(EPOCH FROM time - LAG(time) OVER (ORDER BY height)) AS seconds
+  FROM recent
+  WHERE canonical = TRUE
+)
 SELECT
   MIN(height) start_height,
   MAX(height) end_height,
   MIN(time) start_time,
   MAX(time) end_time,
+  MAX(time) AS timestamp,
   COUNT(*) count,
   EXTRACT(EPOCH FROM MAX(time) - MIN(time)) AS diff,
-  EXTRACT(EPOCH FROM ((MAX(time) - MIN(time)) / COUNT(*))) AS avg
+  EXTRACT(EPOCH FROM ((MAX(time) - MIN(time)) / COUNT(*))) AS avg,
+  (SELECT percentile_cont(0.5) WITHIN GROUP (ORDER BY seconds) FROM intervals) AS p50_seconds,
+  (SELECT percentile_cont(0.95) WITHIN GROUP (ORDER BY seconds) FROM intervals) AS p95_seconds,
+  (SELECT percentile_cont(0.99) WITHIN GROUP (ORDER BY seconds) FROM intervals) AS p99_seconds,
+  (SELECT COUNT(seconds) FROM intervals) AS sample_size
 FROM
-  (
-    SELECT * FROM blocks
-    ORDER BY height DESC
-    LIMIT ?
-  ) t
\ No newline at end of file
+  recent
@@ -0,0 +1,16 @@
+WITH intervals AS (
+  SELECT
+    time,
+    EXTRACT(EPOCH FROM time - LAG(time) OVER (ORDER BY height)) AS seconds
+  FROM blocks
+  WHERE
+    canonical = TRUE
+    AND time >= NOW() - $2::INTERVAL
+)
+SELECT
+  DATE_TRUNC($1, time) AS timestamp,
+  COALESCE(AVG(seconds), 0) AS avg_seconds,
+  COUNT(1) AS block_count
+FROM intervals
+GROUP BY 1
+ORDER BY 1 ASC
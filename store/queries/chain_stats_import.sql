@@ -5,6 +5,15 @@ WITH current_ledger AS (
     WHERE epoch = (SELECT MAX(epoch) FROM blocks WHERE time >= $1 AND time <= $2)
     LIMIT 1
   )
+),
+window_jobs AS (
+  SELECT snark_jobs.fee FROM snark_jobs
+  INNER JOIN blocks
+    ON blocks.hash = snark_jobs.block_hash
+  WHERE
+    blocks.time >= $1
+    AND blocks.time <= $2
+    AND blocks.canonical = TRUE
 )
 INSERT INTO chain_stats (
   time,
@@ -31,7 +40,9 @@ INSERT INTO chain_stats (
   coinbase_amount,
   staked_amount,
   delegations_count,
-  delegations_amount
+  delegations_amount,
+  min_snark_fee,
+  max_snark_fee
 )
 SELECT
   DATE_TRUNC('@bucket', blocks.time),
@@ -58,7 +69,9 @@ SELECT
   COALESCE(SUM(transactions.amount) FILTER (WHERE type = 'coinbase'), 0),
   COALESCE((SELECT SUM(balance) FROM current_ledger), 0),
   COALESCE((SELECT COUNT(1) FROM current_ledger WHERE delegation IS TRUE), 0),
-  COALESCE((SELECT SUM(balance) FROM current_ledger WHERE delegation IS TRUE), 0)
+  COALESCE((SELECT SUM(balance) FROM current_ledger WHERE delegation IS TRUE), 0),
+  COALESCE((SELECT MIN(fee) FROM window_jobs), 0),
+  COALESCE((SELECT MAX(fee) FROM window_jobs), 0)
 FROM
   blocks
 LEFT JOIN transactions
@@ -0,0 +1,15 @@
+INSERT INTO failed_blocks (
+  height,
+  hash,
+  error,
+  attempts,
+  created_at,
+  updated_at
+)
+VALUES ($1, $2, $3, 1, NOW(), NOW())
+ON CONFLICT (height) DO UPDATE
+SET
+  hash = excluded.hash,
+  error = excluded.error,
+  attempts = failed_blocks.attempts + 1,
+  updated_at = excluded.updated_at
@@ -1 +1 @@
-UPDATE blocks SET canonical = true WHERE hash = $1
\ No newline at end of file
+UPDATE blocks SET canonical = true, orphaned = false WHERE hash = $1
\ No newline at end of file
@@ -0,0 +1,11 @@
+INSERT INTO metadata (
+  key,
+  value,
+  created_at,
+  updated_at
+)
+VALUES ($1, $2, NOW(), NOW())
+ON CONFLICT (key) DO UPDATE
+SET
+  value = excluded.value,
+  updated_at = excluded.updated_at
@@ -0,0 +1,14 @@
+SELECT
+  snark_jobs.prover AS public_key,
+  COUNT(1) AS jobs_count,
+  SUM(snark_jobs.works_count) AS works_count,
+  COALESCE(SUM(snark_jobs.fee), 0)::TEXT AS fee_total
+FROM
+  snark_jobs
+WHERE
+  snark_jobs.block_hash = $1
+GROUP BY
+  snark_jobs.prover
+ORDER BY
+  SUM(snark_jobs.fee) DESC NULLS LAST,
+  public_key ASC
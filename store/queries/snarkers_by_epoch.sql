@@ -0,0 +1,17 @@
+SELECT
+  snark_jobs.prover AS public_key,
+  COUNT(1) AS jobs_count,
+  SUM(snark_jobs.works_count) AS works_count,
+  COALESCE(SUM(snark_jobs.fee), 0)::TEXT AS total_fees,
+  COALESCE(ROUND(AVG(snark_jobs.fee)), 0)::TEXT AS avg_fee
+FROM
+  snark_jobs
+INNER JOIN blocks
+  ON blocks.hash = snark_jobs.block_hash
+WHERE
+  blocks.epoch = $1
+  AND blocks.canonical = TRUE
+GROUP BY
+  snark_jobs.prover
+ORDER BY
+  jobs_count DESC
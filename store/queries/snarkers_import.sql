@@ -1,6 +1,8 @@
 INSERT INTO snarkers (
   account,
   fee,
+  fee_total,
+  avg_fee,
   jobs_count,
   works_count,
   start_height,
@@ -14,6 +16,8 @@ VALUES @values
 ON CONFLICT (account) DO UPDATE
 SET
   fee         = excluded.fee,
+  fee_total   = snarkers.fee_total + excluded.fee_total,
+  avg_fee     = DIV(snarkers.fee_total + excluded.fee_total, GREATEST(snarkers.jobs_count + excluded.jobs_count, 1)),
   jobs_count  = snarkers.jobs_count + excluded.jobs_count,
   works_count = snarkers.works_count + excluded.works_count,
   last_height = excluded.last_height,
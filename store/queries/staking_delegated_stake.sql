@@ -0,0 +1,7 @@
+SELECT
+  COALESCE(SUM(balance), 0)::TEXT AS total,
+  COALESCE(SUM(balance) FILTER (WHERE delegate = $2), 0)::TEXT AS delegated
+FROM
+  ledger_entries
+WHERE
+  ledger_id = $1
@@ -0,0 +1,26 @@
+WITH entries_before AS (
+  SELECT public_key, balance
+  FROM ledger_entries
+  WHERE
+    ledger_id = (SELECT id FROM ledgers WHERE epoch = $2 ORDER BY id DESC LIMIT 1)
+    AND delegate = $1
+    AND delegation = TRUE
+),
+entries_after AS (
+  SELECT public_key, balance
+  FROM ledger_entries
+  WHERE
+    ledger_id = (SELECT id FROM ledgers WHERE epoch = $3 ORDER BY id DESC LIMIT 1)
+    AND delegate = $1
+    AND delegation = TRUE
+)
+SELECT
+  COALESCE(entries_after.public_key, entries_before.public_key) AS public_key,
+  entries_before.balance::TEXT AS balance_before,
+  entries_after.balance::TEXT AS balance_after
+FROM
+  entries_before
+FULL OUTER JOIN entries_after
+  ON entries_after.public_key = entries_before.public_key
+ORDER BY
+  public_key ASC
@@ -0,0 +1,11 @@
+SELECT
+  TO_CHAR(days.date, 'YYYY-MM-DD') AS date,
+  COALESCE(daily_tx_volume.tx_count, 0) AS tx_count,
+  COALESCE(daily_tx_volume.total_amount, 0)::TEXT AS total_amount,
+  COALESCE(daily_tx_volume.total_fees, 0)::TEXT AS total_fees
+FROM
+  GENERATE_SERIES(CURRENT_DATE - ($1::INTEGER - 1), CURRENT_DATE, INTERVAL '1 day') AS days(date)
+LEFT JOIN daily_tx_volume
+  ON daily_tx_volume.date = days.date::DATE
+ORDER BY
+  days.date ASC
@@ -0,0 +1,25 @@
+INSERT INTO daily_tx_volume (
+  date,
+  tx_count,
+  total_amount,
+  total_fees,
+  updated_at
+)
+SELECT
+  $1::DATE,
+  COUNT(1),
+  COALESCE(SUM(amount), 0),
+  COALESCE(SUM(fee), 0),
+  NOW()
+FROM
+  transactions
+WHERE
+  canonical = TRUE
+  AND time >= $1::DATE::TIMESTAMP AT TIME ZONE 'UTC'
+  AND time < ($1::DATE + INTERVAL '1 day') AT TIME ZONE 'UTC'
+ON CONFLICT (date) DO UPDATE
+SET
+  tx_count     = excluded.tx_count,
+  total_amount = excluded.total_amount,
+  total_fees   = excluded.total_fees,
+  updated_at   = excluded.updated_at
@@ -0,0 +1,14 @@
+SELECT
+  (SELECT COUNT(1) FROM accounts) AS total_accounts,
+  (SELECT COUNT(1) FROM transactions WHERE canonical = TRUE) AS total_transactions,
+  (SELECT COALESCE(staked_amount, 0) FROM ledgers ORDER BY epoch DESC LIMIT 1) AS total_staked,
+  (SELECT COALESCE(MAX(epoch), 0) FROM blocks WHERE canonical = TRUE) AS latest_epoch,
+  (
+    SELECT COUNT(1) FROM snark_jobs
+    INNER JOIN blocks ON blocks.hash = snark_jobs.block_hash
+    WHERE blocks.canonical = TRUE
+  ) AS total_snark_jobs,
+  (
+    SELECT COALESCE(SUM(amount), 0) FROM transactions
+    WHERE type = 'coinbase' AND canonical = TRUE
+  ) AS total_rewards_paid
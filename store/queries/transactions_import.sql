@@ -4,17 +4,20 @@ INSERT INTO transactions (
   block_hash,
   block_height,
   time,
+  timestamp,
   nonce,
   sender,
   receiver,
   amount,
   fee,
   memo,
+  memo_hash,
   status,
   canonical,
   failure_reason,
   sequence_number,
   secondary_sequence_number,
+  zkapp_body,
   created_at,
   updated_at
 )
@@ -26,7 +29,9 @@ SET
   receiver       = excluded.receiver,
   amount         = excluded.amount,
   fee            = excluded.fee,
+  timestamp      = excluded.timestamp,
   status         = excluded.status,
   canonical      = excluded.canonical,
   failure_reason = excluded.failure_reason,
+  zkapp_body     = excluded.zkapp_body,
   updated_at     = excluded.updated_at
\ No newline at end of file
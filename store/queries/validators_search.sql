@@ -1,3 +1,11 @@
+WITH produced_blocks AS (
+  SELECT
+    creator,
+    COUNT(1) AS blocks_count
+  FROM blocks
+  WHERE canonical = TRUE
+  GROUP BY creator
+)
 SELECT
   validators.public_key,
   validators.identity_name,
@@ -5,16 +13,22 @@ SELECT
   validators.start_time,
   validators.last_height,
   validators.last_time,
-  COALESCE(validators.stake::TEXT) AS stake,
   validators.blocks_created,
   validators.blocks_proposed,
   validators.delegations,
   COALESCE(validators.stake, 0)::TEXT AS stake,
   COALESCE(accounts.balance, 0)::TEXT AS account_balance,
-  COALESCE(accounts.balance_unknown, 0)::TEXT AS account_balance_unknown
+  COALESCE(accounts.balance_unknown, 0)::TEXT AS account_balance_unknown,
+  COALESCE(produced_blocks.blocks_count, 0) AS blocks_count,
+  COALESCE(validators.stake, 0)::TEXT AS stake_amount,
+  validators.stake_rank
 FROM
   validators
 LEFT JOIN accounts
   ON accounts.public_key = validators.public_key
+LEFT JOIN produced_blocks
+  ON produced_blocks.creator = validators.public_key
+WHERE
+  ($1::TEXT IS NULL OR validators.identity_name ILIKE $1)
 ORDER BY
-  blocks_created DESC
\ No newline at end of file
+  @order
@@ -0,0 +1,53 @@
+WITH ledger_stakes AS (
+  SELECT
+    delegate,
+    SUM(balance) AS stake_amount,
+    RANK() OVER (ORDER BY SUM(balance) DESC) AS stake_rank
+  FROM ledger_entries
+  WHERE ledger_id = (
+    SELECT id FROM ledgers
+    WHERE epoch = $1
+    ORDER BY id DESC
+    LIMIT 1
+  )
+  GROUP BY delegate
+),
+produced_blocks AS (
+  SELECT
+    creator,
+    COUNT(1) AS blocks_count
+  FROM blocks
+  WHERE
+    canonical = TRUE
+    AND epoch = $1
+  GROUP BY creator
+)
+SELECT
+  validators.public_key,
+  validators.identity_name,
+  validators.start_height,
+  validators.start_time,
+  validators.last_height,
+  validators.last_time,
+  validators.blocks_created,
+  validators.blocks_proposed,
+  validators.delegations,
+  COALESCE(validators.stake, 0)::TEXT AS stake,
+  COALESCE(accounts.balance, 0)::TEXT AS account_balance,
+  COALESCE(accounts.balance_unknown, 0)::TEXT AS account_balance_unknown,
+  COALESCE(produced_blocks.blocks_count, 0) AS blocks_count,
+  COALESCE(ledger_stakes.stake_amount, 0)::TEXT AS stake_amount,
+  ledger_stakes.stake_rank
+FROM
+  validators
+LEFT JOIN accounts
+  ON accounts.public_key = validators.public_key
+LEFT JOIN ledger_stakes
+  ON ledger_stakes.delegate = validators.public_key
+LEFT JOIN produced_blocks
+  ON produced_blocks.creator = validators.public_key
+WHERE
+  (ledger_stakes.delegate IS NOT NULL OR produced_blocks.creator IS NOT NULL)
+  AND ($2::TEXT IS NULL OR validators.identity_name ILIKE $2)
+ORDER BY
+  @order
@@ -0,0 +1,19 @@
+WITH ranks AS (
+  SELECT
+    delegate,
+    ROW_NUMBER() OVER (ORDER BY SUM(balance) DESC) AS stake_rank
+  FROM ledger_entries
+  WHERE ledger_id = (
+    SELECT id FROM ledgers
+    WHERE epoch = $1::INTEGER
+    ORDER BY id DESC
+    LIMIT 1
+  )
+  GROUP BY delegate
+)
+UPDATE validators
+SET
+  stake_rank = (
+    SELECT ranks.stake_rank FROM ranks
+    WHERE ranks.delegate = validators.public_key
+  )
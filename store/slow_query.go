@@ -0,0 +1,133 @@
+package store
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	log "github.com/sirupsen/logrus"
+)
+
+var rePublicKey = regexp.MustCompile(`B62[1-9A-HJ-NP-Za-km-z]+`)
+
+const (
+	slowQueryStartKey = "mina:query_start"
+	storePackage      = "github.com/figment-networks/mina-indexer/store."
+)
+
+// SetSlowQueryThreshold enables query plan logging for queries running longer than the threshold
+func (s *Store) SetSlowQueryThreshold(threshold time.Duration) {
+	if threshold <= 0 {
+		return
+	}
+
+	start := func(scope *gorm.Scope) {
+		scope.InstanceSet(slowQueryStartKey, time.Now())
+	}
+	finish := func(scope *gorm.Scope) {
+		logSlowQuery(scope, threshold)
+	}
+
+	for _, conn := range s.conns() {
+		conn.Callback().Query().Before("gorm:query").Register("mina:slow_query_start", start)
+		conn.Callback().Query().After("gorm:query").Register("mina:slow_query_log", finish)
+		conn.Callback().RowQuery().Before("gorm:row_query").Register("mina:slow_query_start", start)
+		conn.Callback().RowQuery().After("gorm:row_query").Register("mina:slow_query_log", finish)
+	}
+}
+
+func logSlowQuery(scope *gorm.Scope, threshold time.Duration) {
+	val, ok := scope.InstanceGet(slowQueryStartKey)
+	if !ok {
+		return
+	}
+
+	duration := time.Since(val.(time.Time))
+	if duration < threshold {
+		return
+	}
+
+	entry := log.
+		WithField("method", storeMethod()).
+		WithField("duration", duration.Milliseconds()).
+		WithField("params", redactParams(scope.SQLVars))
+
+	// Only the estimated plan is logged, the statement is not executed again
+	query := strings.TrimSpace(scope.SQL)
+	if !isReadQuery(query) {
+		entry.Warn("slow query")
+		return
+	}
+
+	plan, err := explainQuery(scope, query)
+	if err != nil {
+		entry.WithError(err).Warn("slow query")
+		return
+	}
+
+	entry.WithField("plan", plan).Warn("slow query")
+}
+
+func explainQuery(scope *gorm.Scope, query string) (string, error) {
+	rows, err := scope.SQLDB().Query("EXPLAIN "+query, scope.SQLVars...)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	lines := []string{}
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return "", err
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n"), rows.Err()
+}
+
+// storeMethod returns the name of the outermost store method that issued the query
+func storeMethod() string {
+	pc := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pc[:runtime.Callers(3, pc)])
+
+	method := "unknown"
+	for {
+		frame, more := frames.Next()
+		if strings.HasPrefix(frame.Function, storePackage) {
+			method = strings.TrimPrefix(frame.Function, storePackage)
+		}
+		if !more {
+			return method
+		}
+	}
+}
+
+// isReadQuery returns true for SELECT statements. Statements with a WITH clause
+// are skipped since the clause may contain data modifying statements.
+func isReadQuery(query string) bool {
+	return strings.HasPrefix(strings.ToUpper(query), "SELECT")
+}
+
+// redactParams returns query params with the account public keys removed,
+// including the keys within array params
+func redactParams(vars []interface{}) []string {
+	result := make([]string, len(vars))
+	for i, v := range vars {
+		if valuer, ok := v.(driver.Valuer); ok {
+			if val, err := valuer.Value(); err == nil {
+				v = val
+			}
+		}
+		if b, ok := v.([]byte); ok {
+			v = string(b)
+		}
+		result[i] = rePublicKey.ReplaceAllString(fmt.Sprintf("%v", v), "[redacted]")
+	}
+	return result
+}
@@ -0,0 +1,33 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactParams(t *testing.T) {
+	key := "B62qrPN5Y5yq8kGE3FbVKbGTdTAJNdtNtB5sNVpxyRwWGcDEhpMzc8g"
+
+	params := redactParams([]interface{}{
+		key,
+		pq.Array([]string{key, key}),
+		100,
+		"%" + key + "%",
+	})
+
+	assert.Equal(t, []string{
+		"[redacted]",
+		"{\"[redacted]\",\"[redacted]\"}",
+		"100",
+		"%[redacted]%",
+	}, params)
+}
+
+func TestIsReadQuery(t *testing.T) {
+	assert.True(t, isReadQuery("SELECT * FROM blocks"))
+	assert.True(t, isReadQuery("select count(1) from blocks"))
+	assert.False(t, isReadQuery("WITH moved AS (DELETE FROM blocks RETURNING *) SELECT * FROM moved"))
+	assert.False(t, isReadQuery("UPDATE blocks SET canonical = true"))
+}
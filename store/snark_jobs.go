@@ -17,7 +17,7 @@ type JobsStore struct {
 func (s JobsStore) ByHeight(height uint64) ([]model.SnarkJob, error) {
 	result := []model.SnarkJob{}
 
-	err := s.db.
+	err := s.readDB.
 		Where("height = ?", height).
 		Order("id ASC").
 		Find(&result).
@@ -30,7 +30,7 @@ func (s JobsStore) ByHeight(height uint64) ([]model.SnarkJob, error) {
 func (s JobsStore) ByHash(hash string) ([]model.SnarkJob, error) {
 	result := []model.SnarkJob{}
 
-	err := s.db.
+	err := s.readDB.
 		Where("block_hash = ?", hash).
 		Order("id ASC").
 		Find(&result).
@@ -1,11 +1,13 @@
 package store
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/figment-networks/indexing-engine/store/bulk"
 	"github.com/figment-networks/indexing-engine/store/jsonquery"
 	"github.com/figment-networks/mina-indexer/model"
+	"github.com/figment-networks/mina-indexer/model/types"
 	"github.com/figment-networks/mina-indexer/store/queries"
 )
 
@@ -15,7 +17,7 @@ type SnarkersStore struct {
 
 func (s SnarkersStore) All() ([]model.Snarker, error) {
 	result := []model.Snarker{}
-	err := s.db.
+	err := s.readDB.
 		Model(&model.Snarker{}).
 		Order("jobs_count DESC").
 		Find(&result).
@@ -23,16 +25,81 @@ func (s SnarkersStore) All() ([]model.Snarker, error) {
 	return result, checkErr(err)
 }
 
+// Search returns snarkers matching the search params
+func (s SnarkersStore) Search(search *SnarkerSearch) ([]model.Snarker, error) {
+	result := []model.Snarker{}
+
+	column := search.orderColumn()
+
+	scope := s.readDB.
+		Model(&model.Snarker{}).
+		Order(fmt.Sprintf("%s %s, account %s", column, search.Dir, search.Dir)).
+		Limit(search.Limit)
+
+	if search.minFee != nil {
+		scope = scope.Where("avg_fee >= ?", *search.minFee)
+	}
+	if search.maxFee != nil {
+		scope = scope.Where("avg_fee <= ?", *search.maxFee)
+	}
+
+	if search.After != "" {
+		op := ">"
+		if search.Dir == "desc" {
+			op = "<"
+		}
+		scope = scope.Where(
+			fmt.Sprintf("(%s, account) %s (SELECT %s, account FROM snarkers WHERE account = ?)", column, op, column),
+			search.After,
+		)
+	}
+
+	err := scope.Find(&result).Error
+	return result, checkErr(err)
+}
+
+// ByFeeRange returns snarkers with the average fee within the range, cheapest first.
+// A nil bound leaves the range open on that side.
+func (s SnarkersStore) ByFeeRange(minFee, maxFee types.Amount) ([]model.Snarker, error) {
+	search := &SnarkerSearch{
+		OrderBy: "avg_fee",
+		Dir:     "asc",
+		MinFee:  minFee.String(),
+		MaxFee:  maxFee.String(),
+	}
+	if err := search.Validate(); err != nil {
+		return nil, err
+	}
+	return s.Search(search)
+}
+
+// ByEpoch returns snarkers with jobs included in canonical blocks of the epoch
+func (s SnarkersStore) ByEpoch(epoch int) ([]byte, error) {
+	return jsonquery.MustArray(s.readDB, queries.SnarkersByEpoch, epoch)
+}
+
+// ActiveInBlock returns the provers of the block with their job counts and fees, top earners first
+func (s SnarkersStore) ActiveInBlock(blockHash string) ([]model.BlockSnarker, error) {
+	result := []model.BlockSnarker{}
+	err := s.readDB.Raw(queries.SnarkersActiveInBlock, blockHash).Scan(&result).Error
+	return result, checkErr(err)
+}
+
 // FindSnarker returns snarker for a given account
 func (s SnarkersStore) FindSnarker(account string) (*model.Snarker, error) {
+	return s.FindByPublicKey(account)
+}
+
+// FindByPublicKey returns the snarker with the public key
+func (s SnarkersStore) FindByPublicKey(key string) (*model.Snarker, error) {
 	result := &model.Snarker{}
-	err := findBy(s.db, result, "account", account)
+	err := findBy(s.readDB, result, "account", key)
 	return result, checkErr(err)
 }
 
 // SnarkerInfoFromCanonicalBlocks returns snarker info from canonical blocks
 func (s SnarkersStore) SnarkerInfoFromCanonicalBlocks(account string, start, end uint64) ([]byte, error) {
-	return jsonquery.MustObject(s.db, queries.SnarkerInfoFromCanonicalBlocks, account, start, end)
+	return jsonquery.MustObject(s.readDB, queries.SnarkerInfoFromCanonicalBlocks, account, start, end)
 }
 
 func (s SnarkersStore) Import(records []model.Snarker) error {
@@ -48,6 +115,8 @@ func (s SnarkersStore) Import(records []model.Snarker) error {
 		return bulk.Row{
 			r.Account,
 			r.Fee,
+			r.FeeTotal,
+			r.AvgFee,
 			r.JobsCount,
 			r.WorksCount,
 			r.StartHeight,
@@ -0,0 +1,105 @@
+package store
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/figment-networks/mina-indexer/model/types"
+)
+
+// SnarkerSearch contains a snarker search params
+type SnarkerSearch struct {
+	OrderBy string `form:"order_by"`
+	Dir     string `form:"dir"`
+	Limit   uint   `form:"limit"`
+	After   string `form:"after"`
+	MinFee  string `form:"min_fee"`
+	MaxFee  string `form:"max_fee"`
+
+	minFee *types.Amount
+	maxFee *types.Amount
+}
+
+// Validate performs validation on search parameters
+func (search *SnarkerSearch) Validate() error {
+	errs := ValidationErrors{}
+
+	switch search.OrderBy {
+	case "":
+		search.OrderBy = "job_count"
+	case "fee_total", "job_count", "avg_fee":
+	default:
+		errs = append(errs, "invalid order_by field")
+	}
+
+	switch search.Dir {
+	case "":
+		search.Dir = "desc"
+	case "asc", "desc":
+	default:
+		errs = append(errs, "invalid sort direction")
+	}
+
+	var err error
+	if search.minFee, err = parseFee(search.MinFee); err != nil {
+		errs = append(errs, "invalid min_fee value")
+	}
+	if search.maxFee, err = parseFee(search.MaxFee); err != nil {
+		errs = append(errs, "invalid max_fee value")
+	}
+	if search.minFee != nil && search.maxFee != nil && search.minFee.Compare(*search.maxFee) > 0 {
+		errs = append(errs, "min_fee must not be greater than max_fee")
+	}
+
+	if search.Limit == 0 {
+		search.Limit = 100
+	}
+	if search.Limit > 100 {
+		errs = append(errs, "max limit is 100")
+	}
+
+	return errs.errorOrNil()
+}
+
+// IsFeeRangeOnly returns true if the search only limits the average fee range
+func (search *SnarkerSearch) IsFeeRangeOnly() bool {
+	hasRange := search.MinFee != "" || search.MaxFee != ""
+	return hasRange && search.OrderBy == "" && search.Dir == "" && search.Limit == 0 && search.After == ""
+}
+
+// FeeRange returns the parsed fee range, unset bounds are nil amounts
+func (search *SnarkerSearch) FeeRange() (types.Amount, types.Amount) {
+	var minFee, maxFee types.Amount
+	if search.minFee != nil {
+		minFee = *search.minFee
+	}
+	if search.maxFee != nil {
+		maxFee = *search.maxFee
+	}
+	return minFee, maxFee
+}
+
+func (search *SnarkerSearch) orderColumn() string {
+	switch search.OrderBy {
+	case "fee_total":
+		return "fee_total"
+	case "avg_fee":
+		return "avg_fee"
+	default:
+		return "jobs_count"
+	}
+}
+
+// parseFee returns the fee amount or nil when the value is not set
+func parseFee(value string) (*types.Amount, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	n, ok := new(big.Int).SetString(value, 10)
+	if !ok || n.Sign() < 0 {
+		return nil, errors.New("invalid fee")
+	}
+
+	return &types.Amount{Int: n}, nil
+}
@@ -3,6 +3,7 @@ package store
 import (
 	"github.com/figment-networks/indexing-engine/store/bulk"
 	"github.com/figment-networks/mina-indexer/model"
+	"github.com/figment-networks/mina-indexer/model/types"
 	"github.com/figment-networks/mina-indexer/store/queries"
 )
 
@@ -18,6 +19,11 @@ func (s StakingStore) CreateLedger(ledger *model.Ledger) error {
 	return s.Create(ledger)
 }
 
+// UpdateLedger updates an existing ledger record
+func (s StakingStore) UpdateLedger(ledger *model.Ledger) error {
+	return s.Update(ledger)
+}
+
 // CreateLedgerEntries create a batch of ledger entries
 func (s StakingStore) CreateLedgerEntries(records []model.LedgerEntry) error {
 	var err error
@@ -50,11 +56,32 @@ func (s StakingStore) CreateLedgerEntries(records []model.LedgerEntry) error {
 	return nil
 }
 
+// DeleteLedgerEntries removes all entries of the ledger
+func (s StakingStore) DeleteLedgerEntries(ledgerID int) error {
+	return s.db.
+		Where("ledger_id = ?", ledgerID).
+		Delete(&model.LedgerEntry{}).
+		Error
+}
+
+// LedgerEntriesCount returns the number of entries stored for the ledger
+func (s StakingStore) LedgerEntriesCount(ledgerID int) (int, error) {
+	var count int
+
+	err := s.readDB.
+		Model(&model.LedgerEntry{}).
+		Where("ledger_id = ?", ledgerID).
+		Count(&count).
+		Error
+
+	return count, err
+}
+
 // FindLedger returns the most recent ledger of an epoch
 func (s StakingStore) FindLedger(epoch int) (*model.Ledger, error) {
 	ledger := &model.Ledger{}
 
-	err := s.db.
+	err := s.readDB.
 		Model(ledger).
 		Where("epoch = ?", epoch).
 		First(ledger).
@@ -71,7 +98,7 @@ func (s StakingStore) FindLedger(epoch int) (*model.Ledger, error) {
 func (s StakingStore) AllLedgers() ([]model.Ledger, error) {
 	result := []model.Ledger{}
 
-	err := s.db.
+	err := s.readDB.
 		Model(&model.Ledger{}).
 		Order("epoch ASC").
 		Find(&result).
@@ -84,7 +111,7 @@ func (s StakingStore) AllLedgers() ([]model.Ledger, error) {
 func (s StakingStore) LastLedger() (*model.Ledger, error) {
 	ledger := &model.Ledger{}
 
-	err := s.db.
+	err := s.readDB.
 		Model(ledger).
 		Order("id DESC").
 		First(ledger).
@@ -107,7 +134,7 @@ type FindDelegationsParams struct {
 func (s StakingStore) LedgerRecords(ledgerID int) ([]model.LedgerEntry, error) {
 	result := []model.LedgerEntry{}
 
-	err := s.db.
+	err := s.readDB.
 		Model(&model.LedgerEntry{}).
 		Where("ledger_id = ?", ledgerID).
 		Find(&result).
@@ -116,6 +143,94 @@ func (s StakingStore) LedgerRecords(ledgerID int) ([]model.LedgerEntry, error) {
 	return result, checkErr(err)
 }
 
+// DelegatedStake returns the total balance of the ledger and the balance delegated to the validator
+func (s StakingStore) DelegatedStake(ledgerID int, validatorPK string) (*model.DelegatedStake, error) {
+	result := &model.DelegatedStake{}
+	err := s.readDB.Raw(queries.StakingDelegatedStake, ledgerID, validatorPK).Scan(result).Error
+	return result, checkErr(err)
+}
+
+// FindLedgerEntry returns a ledger record for the public key
+func (s StakingStore) FindLedgerEntry(ledgerID int, publicKey string) (*model.LedgerEntry, error) {
+	result := &model.LedgerEntry{}
+
+	err := s.readDB.
+		Model(result).
+		Where("ledger_id = ? AND public_key = ?", ledgerID, publicKey).
+		Take(result).
+		Error
+
+	return result, checkErr(err)
+}
+
+// AccountHistory returns the staking ledger records of an account ordered by epoch.
+// Only the epochs before the given one are returned when after is set.
+func (s StakingStore) AccountHistory(publicKey string, limit int, after int) ([]model.StakingHistoryEntry, error) {
+	result := []model.StakingHistoryEntry{}
+
+	scope := s.readDB.
+		Table("ledger_entries").
+		Select("ledgers.epoch, ledger_entries.balance, ledger_entries.delegate, ledger_entries.timing_initial_minimum_balance").
+		Joins("INNER JOIN ledgers ON ledgers.id = ledger_entries.ledger_id").
+		Where("ledger_entries.public_key = ?", publicKey).
+		Order("ledgers.epoch DESC").
+		Limit(limit)
+
+	if after > 0 {
+		scope = scope.Where("ledgers.epoch < ?", after)
+	}
+
+	err := scope.Scan(&result).Error
+	return result, checkErr(err)
+}
+
+// DelegationDiff returns the delegators of the validator that joined or left between
+// the staking ledgers of the two epochs, along with the delegated stake change
+func (s StakingStore) DelegationDiff(validatorPK string, epochA, epochB int) (*model.DelegationDiff, error) {
+	rows := []delegationDiffRow{}
+
+	err := s.readDB.Raw(queries.StakingDelegationDiff, validatorPK, epochA, epochB).Scan(&rows).Error
+	if err != nil {
+		return nil, checkErr(err)
+	}
+
+	return buildDelegationDiff(epochB, rows), nil
+}
+
+// delegationDiffRow contains the delegator balances in both ledgers, nil when absent
+type delegationDiffRow struct {
+	PublicKey     string
+	BalanceBefore types.Amount
+	BalanceAfter  types.Amount
+}
+
+func buildDelegationDiff(epoch int, rows []delegationDiffRow) *model.DelegationDiff {
+	result := &model.DelegationDiff{
+		Epoch:          epoch,
+		Joined:         []string{},
+		Left:           []string{},
+		NetStakeChange: types.NewInt64Amount(0),
+	}
+
+	for _, row := range rows {
+		switch {
+		case row.BalanceBefore.IsNil():
+			result.Joined = append(result.Joined, row.PublicKey)
+		case row.BalanceAfter.IsNil():
+			result.Left = append(result.Left, row.PublicKey)
+		}
+
+		if !row.BalanceAfter.IsNil() {
+			result.NetStakeChange = result.NetStakeChange.Add(row.BalanceAfter)
+		}
+		if !row.BalanceBefore.IsNil() {
+			result.NetStakeChange = result.NetStakeChange.Sub(row.BalanceBefore)
+		}
+	}
+
+	return result
+}
+
 // FindDelegations returns delegations for a given ledger ID
 func (s StakingStore) FindDelegations(params FindDelegationsParams) ([]model.Delegation, error) {
 	result := []model.Delegation{}
@@ -128,7 +243,7 @@ func (s StakingStore) FindDelegations(params FindDelegationsParams) ([]model.Del
 		params.LedgerID = &ledger.ID
 	}
 
-	scope := s.db.
+	scope := s.readDB.
 		Table("ledger_entries").
 		Where("ledger_id = ?", params.LedgerID).
 		Where("delegation = ?", true)
@@ -0,0 +1,33 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/figment-networks/mina-indexer/model/types"
+)
+
+func TestBuildDelegationDiff(t *testing.T) {
+	rows := []delegationDiffRow{
+		{PublicKey: "joined", BalanceAfter: types.NewInt64Amount(300)},
+		{PublicKey: "left", BalanceBefore: types.NewInt64Amount(100)},
+		{PublicKey: "stayed", BalanceBefore: types.NewInt64Amount(50), BalanceAfter: types.NewInt64Amount(80)},
+	}
+
+	diff := buildDelegationDiff(5, rows)
+
+	assert.Equal(t, 5, diff.Epoch)
+	assert.Equal(t, []string{"joined"}, diff.Joined)
+	assert.Equal(t, []string{"left"}, diff.Left)
+	assert.Equal(t, "230", diff.NetStakeChange.String())
+}
+
+func TestBuildDelegationDiffEmpty(t *testing.T) {
+	diff := buildDelegationDiff(2, nil)
+
+	assert.Equal(t, 2, diff.Epoch)
+	assert.Empty(t, diff.Joined)
+	assert.Empty(t, diff.Left)
+	assert.Equal(t, "0", diff.NetStakeChange.String())
+}
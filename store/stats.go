@@ -13,6 +13,7 @@ import (
 const (
 	BucketHour = "h"
 	BucketDay  = "d"
+	BucketWeek = "w"
 )
 
 type StatsStore struct {
@@ -40,6 +41,26 @@ func (s StatsStore) CreateChainStats(bucket string, ts time.Time) error {
 	).Error
 }
 
+// NetworkSummary returns the network wide totals
+func (s StatsStore) NetworkSummary() (*model.NetworkSummary, error) {
+	result := &model.NetworkSummary{}
+	err := s.readDB.Raw(queries.StatsNetworkSummary).Scan(result).Error
+	return result, checkErr(err)
+}
+
+// CreateDailyTransactionVolume updates the transactions volume of the UTC day
+func (s StatsStore) CreateDailyTransactionVolume(ts time.Time) error {
+	day := ts.UTC().Format("2006-01-02")
+	return s.db.Exec(queries.StatsDailyTxVolumeImport, day).Error
+}
+
+// DailyTransactionVolume returns the transactions volume of the last days, oldest first
+func (s StatsStore) DailyTransactionVolume(days int) ([]model.DayVolume, error) {
+	result := []model.DayVolume{}
+	err := s.readDB.Raw(queries.StatsDailyTxVolume, days).Scan(&result).Error
+	return result, checkErr(err)
+}
+
 // CreateValidatorStats creates a new validator stats record
 func (s StatsStore) CreateValidatorStats(validatorPublicKey string, bucket string, ts time.Time) error {
 	start, end, err := s.getTimeRange(bucket, ts)
@@ -57,7 +78,22 @@ func (s StatsStore) CreateValidatorStats(validatorPublicKey string, bucket strin
 func (s StatsStore) ValidatorStats(validator *model.Validator, period uint, interval string) ([]model.ValidatorStat, error) {
 	result := []model.ValidatorStat{}
 
-	err := s.db.
+	// Weekly stats are not stored and are aggregated from the daily buckets
+	if interval == BucketWeek {
+		err := s.readDB.
+			Table("validator_stats").
+			Select(sqlValidatorWeeklyStats).
+			Where("validator_id = ? AND bucket = ?", validator.ID, BucketDay).
+			Group("1").
+			Order("1 DESC").
+			Limit(period).
+			Scan(&result).
+			Error
+
+		return result, err
+	}
+
+	err := s.readDB.
 		Model(&model.ValidatorStat{}).
 		Where("validator_id = ? AND bucket = ?", validator.ID, interval).
 		Order("time DESC").
@@ -76,7 +112,7 @@ func (s StatsStore) FindValidatorsForDefaultStats(bucket string, ts time.Time) (
 	}
 
 	var res []model.Validator
-	err = s.db.Raw(queries.ValidatorsForDefaultStats, bucket, start).Scan(&res).Error
+	err = s.readDB.Raw(queries.ValidatorsForDefaultStats, bucket, start).Scan(&res).Error
 	if err != nil {
 		return nil, checkErr(err)
 	}
@@ -102,4 +138,11 @@ func (s StatsStore) prepareBucket(q, bucket string) string {
 
 var (
 	sqlChainStatsDelete = `DELETE FROM chain_stats WHERE time = ? AND BUCKET = '@bucket';`
+
+	sqlValidatorWeeklyStats = `
+		DATE_TRUNC('week', time) AS time,
+		'w' AS bucket,
+		SUM(blocks_produced_count) AS blocks_produced_count,
+		MAX(delegations_count) AS delegations_count,
+		MAX(delegations_amount)::TEXT AS delegations_amount`
 )
@@ -11,7 +11,9 @@ import (
 
 // Store handles all database operations
 type Store struct {
-	db *gorm.DB
+	db     *gorm.DB
+	readDB *gorm.DB
+	done   chan struct{}
 
 	Blocks       BlocksStore
 	Accounts     AccountsStore
@@ -21,6 +23,8 @@ type Store struct {
 	Snarkers     SnarkersStore
 	Stats        StatsStore
 	Staking      StakingStore
+	Metadata     MetadataStore
+	FailedBlocks FailedBlocksStore
 }
 
 // Test checks the connection status
@@ -28,8 +32,24 @@ func (s *Store) Test() error {
 	return s.db.DB().Ping()
 }
 
-// Close closes the database connection
+// TestReplica checks the read replica connection status
+func (s *Store) TestReplica() error {
+	return s.readDB.DB().Ping()
+}
+
+// HasReplica returns true if the reads are routed to a separate replica database
+func (s *Store) HasReplica() bool {
+	return s.readDB != s.db
+}
+
+// Close closes the database connections
 func (s *Store) Close() error {
+	close(s.done)
+	if s.HasReplica() {
+		if err := s.readDB.Close(); err != nil {
+			return err
+		}
+	}
 	return s.db.Close()
 }
 
@@ -38,60 +58,132 @@ func (s *Store) Conn() *sql.DB {
 	return s.db.DB()
 }
 
+// SetPoolSize sets the connection pool limits, non-positive values keep the driver defaults
+func (s *Store) SetPoolSize(maxOpen, maxIdle int) {
+	for _, conn := range s.conns() {
+		if maxOpen > 0 {
+			conn.DB().SetMaxOpenConns(maxOpen)
+		}
+		if maxIdle > 0 {
+			conn.DB().SetMaxIdleConns(maxIdle)
+		}
+	}
+}
+
+// SetCaseInsensitiveLookup enables the case insensitive fallback of transaction hash lookups
+func (s *Store) SetCaseInsensitiveLookup(enabled bool) {
+	s.Transactions.caseInsensitiveLookup = enabled
+}
+
 // SetDebugMode enabled detailed query logging
 func (s *Store) SetDebugMode(enabled bool) {
-	s.db.LogMode(enabled)
+	for _, conn := range s.conns() {
+		conn.LogMode(enabled)
+	}
+}
+
+// conns returns all distinct database connections
+func (s *Store) conns() []*gorm.DB {
+	if s.HasReplica() {
+		return []*gorm.DB{s.db, s.readDB}
+	}
+	return []*gorm.DB{s.db}
 }
 
 // New returns a new store from the connection string
 func New(connStr string) (*Store, error) {
+	return NewWithReplica(connStr, "")
+}
+
+// NewWithReplica returns a new store that routes the read-only queries to the replica.
+// The primary database is used for reads when the replica connection string is empty.
+func NewWithReplica(connStr string, replicaConnStr string) (*Store, error) {
 	conn, err := gorm.Open("postgres", connStr)
 	if err != nil {
 		return nil, err
 	}
 
+	readConn := conn
+	if replicaConnStr != "" {
+		readConn, err = gorm.Open("postgres", replicaConnStr)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	s := newStore(conn, readConn)
+	s.done = make(chan struct{})
+
+	go s.monitorPool(poolCheckInterval)
+
+	return s, nil
+}
+
+// Transaction runs fn with a store bound to a single database transaction.
+// The transaction is committed when fn returns no error and rolled back otherwise.
+func (s *Store) Transaction(fn func(tx *Store) error) error {
+	return s.db.Transaction(func(conn *gorm.DB) error {
+		tx := newStore(conn, conn)
+		tx.Transactions.caseInsensitiveLookup = s.Transactions.caseInsensitiveLookup
+		return fn(tx)
+	})
+}
+
+func newStore(conn, readConn *gorm.DB) *Store {
 	return &Store{
-		db: conn,
+		db:     conn,
+		readDB: readConn,
+
+		Blocks:       NewBlocksStore(conn, readConn),
+		Accounts:     NewAccountsStore(conn, readConn),
+		Validators:   NewValidatorsStore(conn, readConn),
+		Transactions: NewTransactionsStore(conn, readConn),
+		Snarkers:     NewSnarkersStore(conn, readConn),
+		Jobs:         NewJobsStore(conn, readConn),
+		Stats:        NewStatsStore(conn, readConn),
+		Staking:      NewStakingStore(conn, readConn),
+		Metadata:     NewMetadataStore(conn, readConn),
+		FailedBlocks: NewFailedBlocksStore(conn, readConn),
+	}
+}
+
+func NewBlocksStore(db, readDB *gorm.DB) BlocksStore {
+	return BlocksStore{scoped(db, readDB, model.Block{})}
+}
 
-		Blocks:       NewBlocksStore(conn),
-		Accounts:     NewAccountsStore(conn),
-		Validators:   NewValidatorsStore(conn),
-		Transactions: NewTransactionsStore(conn),
-		Snarkers:     NewSnarkersStore(conn),
-		Jobs:         NewJobsStore(conn),
-		Stats:        NewStatsStore(conn),
-		Staking:      NewStakingStore(conn),
-	}, nil
+func NewAccountsStore(db, readDB *gorm.DB) AccountsStore {
+	return AccountsStore{scoped(db, readDB, model.Account{})}
 }
 
-func NewBlocksStore(db *gorm.DB) BlocksStore {
-	return BlocksStore{scoped(db, model.Block{})}
+func NewValidatorsStore(db, readDB *gorm.DB) ValidatorsStore {
+	return ValidatorsStore{scoped(db, readDB, model.Validator{})}
 }
 
-func NewAccountsStore(db *gorm.DB) AccountsStore {
-	return AccountsStore{scoped(db, model.Account{})}
+func NewTransactionsStore(db, readDB *gorm.DB) TransactionsStore {
+	return TransactionsStore{baseStore: scoped(db, readDB, model.Transaction{})}
 }
 
-func NewValidatorsStore(db *gorm.DB) ValidatorsStore {
-	return ValidatorsStore{scoped(db, model.Validator{})}
+func NewSnarkersStore(db, readDB *gorm.DB) SnarkersStore {
+	return SnarkersStore{scoped(db, readDB, model.Snarker{})}
 }
 
-func NewTransactionsStore(db *gorm.DB) TransactionsStore {
-	return TransactionsStore{scoped(db, model.Transaction{})}
+func NewJobsStore(db, readDB *gorm.DB) JobsStore {
+	return JobsStore{scoped(db, readDB, model.SnarkJob{})}
 }
 
-func NewSnarkersStore(db *gorm.DB) SnarkersStore {
-	return SnarkersStore{scoped(db, model.Snarker{})}
+func NewStatsStore(db, readDB *gorm.DB) StatsStore {
+	return StatsStore{baseStore{db: db, readDB: readDB}}
 }
 
-func NewJobsStore(db *gorm.DB) JobsStore {
-	return JobsStore{scoped(db, model.SnarkJob{})}
+func NewStakingStore(db, readDB *gorm.DB) StakingStore {
+	return StakingStore{scoped(db, readDB, nil)}
 }
 
-func NewStatsStore(db *gorm.DB) StatsStore {
-	return StatsStore{baseStore{db: db}}
+func NewMetadataStore(db, readDB *gorm.DB) MetadataStore {
+	return MetadataStore{scoped(db, readDB, model.Metadata{})}
 }
 
-func NewStakingStore(db *gorm.DB) StakingStore {
-	return StakingStore{scoped(db, nil)}
+func NewFailedBlocksStore(db, readDB *gorm.DB) FailedBlocksStore {
+	return FailedBlocksStore{scoped(db, readDB, model.FailedBlock{})}
 }
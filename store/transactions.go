@@ -5,20 +5,25 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jinzhu/gorm"
+
 	"github.com/figment-networks/indexing-engine/store/bulk"
 	"github.com/figment-networks/mina-indexer/model"
+	"github.com/figment-networks/mina-indexer/model/types"
 	"github.com/figment-networks/mina-indexer/store/queries"
 )
 
 // TransactionsStore handles operations on transactions
 type TransactionsStore struct {
 	baseStore
+
+	caseInsensitiveLookup bool
 }
 
 // FindBy returns transactions by a given key and value
 func (s TransactionsStore) FindBy(key string, value interface{}) (*model.Transaction, error) {
 	result := &model.Transaction{}
-	err := findBy(s.db, result, key, value)
+	err := findBy(s.readDB, result, key, value)
 	return result, checkErr(err)
 }
 
@@ -27,26 +32,106 @@ func (s TransactionsStore) FindByID(id int64) (*model.Transaction, error) {
 	return s.FindBy("id", id)
 }
 
-// FindByHash returns a transaction for a given hash
+// FindByHash returns a transaction for a given hash.
+// When case insensitive lookup is enabled a hash without an exact match is retried ignoring the case.
 func (s TransactionsStore) FindByHash(hash string) (*model.Transaction, error) {
-	return s.FindBy("hash", hash)
+	result, err := s.FindBy("hash", hash)
+	if err != ErrNotFound || !s.caseInsensitiveLookup {
+		return result, err
+	}
+
+	result = &model.Transaction{}
+	err = s.readDB.Where("LOWER(hash) = LOWER(?)", hash).Take(result).Error
+	return result, checkErr(err)
+}
+
+// MaxHeight returns the most recent block height with indexed transactions
+func (s TransactionsStore) MaxHeight() (uint64, error) {
+	var result struct{ Height uint64 }
+	err := s.readDB.
+		Model(&model.Transaction{}).
+		Select("COALESCE(MAX(block_height), 0) AS height").
+		Scan(&result).
+		Error
+	return result.Height, checkErr(err)
+}
+
+// ByMemoHash returns transactions with the given memo digest, newest first
+func (s TransactionsStore) ByMemoHash(hash string, limit, offset int) ([]model.Transaction, error) {
+	result := []model.Transaction{}
+
+	err := s.readDB.
+		Where("memo_hash = ?", hash).
+		Order("id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&result).
+		Error
+
+	return result, checkErr(err)
+}
+
+// DelegationHistory returns the applied canonical delegations sent by the account, oldest first.
+// Only the delegations included in the given epoch are returned when epoch is set.
+func (s TransactionsStore) DelegationHistory(account string, epoch *int) ([]model.DelegationChange, error) {
+	result := []model.DelegationChange{}
+
+	scope := s.readDB.
+		Table("transactions").
+		Select("transactions.hash, transactions.receiver AS delegate, transactions.block_height, transactions.block_hash, COALESCE(transactions.timestamp, transactions.time) AS timestamp").
+		Where("transactions.sender = ? AND transactions.type = ?", account, model.TxTypeDelegation).
+		Where("transactions.canonical = ? AND transactions.status = ?", true, model.TxStatusApplied).
+		Order("transactions.block_height ASC, transactions.nonce ASC")
+
+	if epoch != nil {
+		scope = scope.
+			Joins("INNER JOIN blocks ON blocks.hash = transactions.block_hash").
+			Where("blocks.epoch = ?", *epoch)
+	}
+
+	err := scope.Scan(&result).Error
+	return result, checkErr(err)
 }
 
 // ByAccount returns a list of transactions sent or received by the account
 func (s TransactionsStore) ByAccount(account string) ([]model.Transaction, error) {
 	var canonical = true
-	return s.Search(TransactionSearch{Account: account, Canonical: &canonical})
+	return s.search(TransactionSearch{Account: account, Canonical: &canonical})
 }
 
 // ByHeight returns transactions for a given height
 func (s TransactionsStore) ByHeight(height uint64, limit uint) ([]model.Transaction, error) {
 	var canonical = true
-	return s.Search(TransactionSearch{Height: height, Limit: limit, Canonical: &canonical})
+	return s.search(TransactionSearch{Height: height, Limit: limit, Canonical: &canonical})
 }
 
-// Search returns a list of transactions that matches the filters
-func (s TransactionsStore) Search(search TransactionSearch) ([]model.Transaction, error) {
-	scope := s.db.
+// SumAmountBySender returns the total amount of applied payments sent by the account
+func (s TransactionsStore) SumAmountBySender(sender string) (types.Amount, error) {
+	result := types.NewInt64Amount(0)
+
+	err := s.readDB.
+		Table("transactions").
+		Select("COALESCE(SUM(amount), 0)").
+		Where("sender = ? AND type = ? AND status = ? AND canonical = ?", sender, model.TxTypePayment, model.TxStatusApplied, true).
+		Row().
+		Scan(&result)
+
+	return result, err
+}
+
+// Search returns a list of transactions that matches the filters and the total count of matches
+func (s TransactionsStore) Search(search TransactionSearch) ([]model.Transaction, int64, error) {
+	count, err := cachedCount(s.filter(search))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result, err := s.search(search)
+	return result, count, err
+}
+
+func (s TransactionsStore) search(search TransactionSearch) ([]model.Transaction, error) {
+	scope := s.filter(search).
 		Order("time DESC").
 		Limit(search.Limit)
 
@@ -56,6 +141,17 @@ func (s TransactionsStore) Search(search TransactionSearch) ([]model.Transaction
 	if search.AfterID > 0 {
 		scope = scope.Where("id > ?", search.AfterID)
 	}
+
+	result := []model.Transaction{}
+	err := scope.Find(&result).Error
+
+	return result, err
+}
+
+// filter returns a scope limited to the search filters, except the cursor ones
+func (s TransactionsStore) filter(search TransactionSearch) *gorm.DB {
+	scope := s.readDB.Model(&model.Transaction{})
+
 	if search.BlockHash != "" {
 		scope = scope.Where("block_hash = ?", search.BlockHash)
 	}
@@ -82,19 +178,16 @@ func (s TransactionsStore) Search(search TransactionSearch) ([]model.Transaction
 		scope = scope.Where("status = ?", search.Status)
 	}
 	if search.startTime != nil {
-		scope = scope.Where("time >= ?", search.startTime)
+		scope = scope.Where("time >= ?", *search.startTime)
 	}
 	if search.endTime != nil {
-		scope = scope.Where("time <= ?", search.endTime)
+		scope = scope.Where("time <= ?", *search.endTime)
 	}
 	if search.Canonical != nil {
 		scope = scope.Where("canonical = ?", *search.Canonical)
 	}
 
-	result := []model.Transaction{}
-	err := scope.Find(&result).Error
-
-	return result, err
+	return scope
 }
 
 func (s TransactionsStore) Import(records []model.Transaction) error {
@@ -112,17 +205,20 @@ func (s TransactionsStore) Import(records []model.Transaction) error {
 			tx.BlockHash,
 			tx.BlockHeight,
 			tx.Time,
+			tx.Timestamp,
 			tx.Nonce,
 			tx.Sender,
 			tx.Receiver,
 			tx.Amount,
 			tx.Fee,
 			tx.Memo,
+			tx.MemoHash,
 			tx.Status,
 			tx.Canonical,
 			tx.FailureReason,
 			tx.SequenceNumber,
 			tx.SecondarySequenceNumber,
+			tx.ZkAppBody,
 			now,
 			now,
 		}
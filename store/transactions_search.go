@@ -1,7 +1,6 @@
 package store
 
 import (
-	"errors"
 	"regexp"
 	"strings"
 	"time"
@@ -36,6 +35,8 @@ type TransactionSearch struct {
 
 // Validate returns an error if search form is invalid
 func (s *TransactionSearch) Validate() error {
+	errs := ValidationErrors{}
+
 	if s.Type != "" {
 		types := strings.Split(strings.ToLower(s.Type), ",")
 		for _, t := range types {
@@ -47,7 +48,7 @@ func (s *TransactionSearch) Validate() error {
 				}
 			}
 			if !found {
-				return errors.New("invalid transaction type: " + t)
+				errs = append(errs, "invalid transaction type: "+t)
 			}
 		}
 	}
@@ -55,33 +56,33 @@ func (s *TransactionSearch) Validate() error {
 	if t, err := parseTimeFilter(s.StartTime); err == nil {
 		s.startTime = t
 	} else {
-		return errors.New("start time is invalid")
+		errs = append(errs, "start time is invalid")
 	}
 	if t, err := parseTimeFilter(s.EndTime); err == nil {
 		s.endTime = t
 	} else {
-		return errors.New("end time is invalid")
+		errs = append(errs, "end time is invalid")
 	}
 
 	if s.BeforeID > 0 && s.AfterID > 0 {
-		return errors.New("can't use both before/after ids")
+		errs = append(errs, "can't use both before/after ids")
 	}
 
 	if s.BlockHash != "" {
 		if s.BeforeID > 0 || s.AfterID > 0 {
-			return errors.New("can't use before/after with block hash")
+			errs = append(errs, "can't use before/after with block hash")
 		}
 		if s.Height > 0 {
-			return errors.New("can't use height with block hash")
+			errs = append(errs, "can't use height with block hash")
 		}
 	}
 
 	if s.startTime != nil && s.endTime != nil && s.endTime.Before(*s.startTime) {
-		return errors.New("end time must be greater than start time")
+		errs = append(errs, "end time must be greater than start time")
 	}
 
 	if s.Status != "" && !(s.Status == "applied" || s.Status == "failed") {
-		return errors.New("invalid transaction status")
+		errs = append(errs, "invalid transaction status")
 	}
 
 	if s.Limit == 0 {
@@ -93,7 +94,7 @@ func (s *TransactionSearch) Validate() error {
 
 	s.Memo = strings.TrimSpace(strings.ToLower(s.Memo))
 
-	return nil
+	return errs.errorOrNil()
 }
 
 func parseTimeFilter(input string) (*time.Time, error) {
@@ -0,0 +1,20 @@
+package store
+
+import (
+	"strings"
+)
+
+// ValidationErrors contains all problems found in search params
+type ValidationErrors []string
+
+// Error returns all messages joined together
+func (e ValidationErrors) Error() string {
+	return strings.Join(e, ", ")
+}
+
+func (e ValidationErrors) errorOrNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
@@ -1,6 +1,7 @@
 package store
 
 import (
+	"strings"
 	"time"
 
 	"github.com/figment-networks/indexing-engine/store/bulk"
@@ -15,20 +16,58 @@ type ValidatorsStore struct {
 	baseStore
 }
 
-func (s ValidatorsStore) Index() ([]byte, error) {
-	return jsonquery.MustArray(s.db, queries.ValidatorsIndex)
+// Search returns validators matching the search params
+func (s ValidatorsStore) Search(search *ValidatorSearch) ([]byte, error) {
+	if search.Epoch == nil {
+		q := strings.Replace(queries.ValidatorsSearch, "@order", search.orderClause(), 1)
+		return jsonquery.MustArray(s.readDB, q, search.namePattern())
+	}
+
+	q := strings.Replace(queries.ValidatorsSearchEpoch, "@order", search.orderClause(), 1)
+	return jsonquery.MustArray(s.readDB, q, search.Epoch, search.namePattern())
+}
+
+// SearchByName returns validators with identity name matching the substring
+func (s ValidatorsStore) SearchByName(name string) ([]model.Validator, error) {
+	result := []model.Validator{}
+
+	err := s.readDB.
+		Where("identity_name ILIKE ?", "%"+likeEscaper.Replace(name)+"%").
+		Order("blocks_created DESC").
+		Find(&result).
+		Error
+
+	return result, checkErr(err)
+}
+
+// TopByBlocksInEpoch returns validators ranked by canonical blocks produced in the epoch
+func (s ValidatorsStore) TopByBlocksInEpoch(epoch string, limit int) ([]model.EpochValidator, error) {
+	result := []model.EpochValidator{}
+
+	err := s.readDB.
+		Table("validators").
+		Select("validators.*, COUNT(blocks.id) AS blocks_produced").
+		Joins("INNER JOIN blocks ON blocks.creator = validators.public_key").
+		Where("blocks.epoch = ? AND blocks.canonical = ?", epoch, true).
+		Group("validators.id").
+		Order("blocks_produced DESC, validators.public_key ASC").
+		Limit(limit).
+		Scan(&result).
+		Error
+
+	return result, checkErr(err)
 }
 
 // FindAll returns all available validators
 func (s ValidatorsStore) FindAll() (result []model.Validator, err error) {
-	err = s.db.Order("blocks_created DESC").Find(&result).Error
+	err = s.readDB.Order("blocks_created DESC").Find(&result).Error
 	return
 }
 
 // FindByPublicKey returns a validator record associated with a key
 func (s ValidatorsStore) FindByPublicKey(key string) (*model.Validator, error) {
 	result := &model.Validator{}
-	err := findBy(s.db, result, "public_key", key)
+	err := findBy(s.readDB, result, "public_key", key)
 	return result, checkErr(err)
 }
 
@@ -36,6 +75,11 @@ func (s ValidatorsStore) UpdateStaking() error {
 	return s.db.Exec(queries.ValidatorsUpdateStaking).Error
 }
 
+// UpdateRanks updates the validators stake rank using the epoch staking ledger
+func (s ValidatorsStore) UpdateRanks(epoch string) error {
+	return s.db.Exec(queries.ValidatorsUpdateRanks, epoch).Error
+}
+
 // UpdateIdentity updates the identity name of the validator
 func (s ValidatorsStore) UpdateIdentity(key string, name string) error {
 	return s.db.Exec(
@@ -0,0 +1,82 @@
+package store
+
+import (
+	"strings"
+)
+
+// ValidatorSearch contains a validator search params
+type ValidatorSearch struct {
+	Epoch   *int   `form:"epoch"`
+	Name    string `form:"name"`
+	OrderBy string `form:"order_by"`
+	Dir     string `form:"dir"`
+}
+
+// Validate performs validation on search parameters
+func (search *ValidatorSearch) Validate() error {
+	errs := ValidationErrors{}
+
+	switch search.OrderBy {
+	case "", "stake", "blocks", "rank":
+	default:
+		errs = append(errs, "invalid order field")
+	}
+
+	switch search.Dir {
+	case "":
+		search.Dir = "desc"
+		if search.OrderBy == "rank" {
+			search.Dir = "asc"
+		}
+	case "asc", "desc":
+	default:
+		errs = append(errs, "invalid order direction")
+	}
+
+	if search.Epoch != nil && *search.Epoch < 0 {
+		errs = append(errs, "epoch is invalid")
+	}
+
+	search.Name = strings.TrimSpace(search.Name)
+	if search.Name != "" && len(search.Name) < 3 {
+		errs = append(errs, "name must be at least 3 characters")
+	}
+
+	return errs.errorOrNil()
+}
+
+// IsNameOnly returns true if the search only filters by the identity name
+func (search *ValidatorSearch) IsNameOnly() bool {
+	return strings.TrimSpace(search.Name) != "" && search.Epoch == nil && search.OrderBy == "" && search.Dir == ""
+}
+
+// orderClause returns the SQL order clause for the search
+func (search *ValidatorSearch) orderClause() string {
+	column := "validators.blocks_created"
+	switch search.OrderBy {
+	case "blocks":
+		column = "blocks_count"
+	case "stake":
+		column = "COALESCE(validators.stake, 0)"
+		if search.Epoch != nil {
+			column = "COALESCE(ledger_stakes.stake_amount, 0)"
+		}
+	case "rank":
+		rank := "validators.stake_rank"
+		if search.Epoch != nil {
+			rank = "ledger_stakes.stake_rank"
+		}
+		// Unranked validators are listed last in both directions
+		return rank + " " + search.Dir + " NULLS LAST, validators.id ASC"
+	}
+	return column + " " + search.Dir + ", validators.id ASC"
+}
+
+// namePattern returns the identity name pattern or nil when name is not set
+func (search *ValidatorSearch) namePattern() *string {
+	if search.Name == "" {
+		return nil
+	}
+	pattern := "%" + likeEscaper.Replace(search.Name) + "%"
+	return &pattern
+}
@@ -0,0 +1,35 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatorSearchRankOrder(t *testing.T) {
+	search := &ValidatorSearch{OrderBy: "rank"}
+	assert.NoError(t, search.Validate())
+	assert.Equal(t, "validators.stake_rank asc NULLS LAST, validators.id ASC", search.orderClause())
+
+	search = &ValidatorSearch{OrderBy: "rank", Dir: "desc"}
+	assert.NoError(t, search.Validate())
+	assert.Equal(t, "validators.stake_rank desc NULLS LAST, validators.id ASC", search.orderClause())
+}
+
+func TestValidatorSearchDefaultOrder(t *testing.T) {
+	search := &ValidatorSearch{}
+	assert.NoError(t, search.Validate())
+	assert.Equal(t, "validators.blocks_created desc, validators.id ASC", search.orderClause())
+}
+
+func TestValidatorSearchEpochOrder(t *testing.T) {
+	epoch := 10
+
+	search := &ValidatorSearch{Epoch: &epoch, OrderBy: "stake"}
+	assert.NoError(t, search.Validate())
+	assert.Equal(t, "COALESCE(ledger_stakes.stake_amount, 0) desc, validators.id ASC", search.orderClause())
+
+	search = &ValidatorSearch{Epoch: &epoch, OrderBy: "rank"}
+	assert.NoError(t, search.Validate())
+	assert.Equal(t, "ledger_stakes.stake_rank asc NULLS LAST, validators.id ASC", search.orderClause())
+}
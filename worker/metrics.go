@@ -0,0 +1,16 @@
+package worker
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	transactionLagGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "mina_indexer_transaction_lag_blocks",
+		Help: "Number of indexed blocks without their transactions",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(transactionLagGauge)
+}
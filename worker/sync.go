@@ -4,7 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
+	"strconv"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -17,20 +18,23 @@ import (
 	"github.com/figment-networks/mina-indexer/store"
 )
 
-const unsafeBlockThreshold = 15
+const (
+	unsafeBlockThreshold = 15
+	syncFromHeightKey    = "sync_from_height"
+)
 
 type SyncWorker struct {
 	cfg           *config.Config
 	db            *store.Store
 	graphClient   *graph.Client
-	archiveClient *archive.Client
+	archiveClient archive.Client
 }
 
 func NewSyncWorker(
 	cfg *config.Config,
 	db *store.Store,
 	graphClient *graph.Client,
-	archiveClient *archive.Client,
+	archiveClient archive.Client,
 ) SyncWorker {
 	return SyncWorker{
 		cfg:           cfg,
@@ -40,7 +44,7 @@ func NewSyncWorker(
 	}
 }
 
-func (w SyncWorker) Run() (int, error) {
+func (w SyncWorker) Run(ctx context.Context) (int, error) {
 	log.Info("starting sync")
 
 	status, err := w.checkNodeStatus()
@@ -49,8 +53,7 @@ func (w SyncWorker) Run() (int, error) {
 	}
 
 	log.Info("processing staking ledger")
-	_, err = w.processStakingLedger()
-	if err != nil {
+	if err := w.processStakingLedger(); err != nil {
 		return 0, err
 	}
 
@@ -69,6 +72,12 @@ func (w SyncWorker) Run() (int, error) {
 	}
 	if lastBlock != nil {
 		blocksRequest.StartHeight = uint(lastBlock.Height+1)
+	} else if from := w.cfg.SyncFromHeight; from > 1 {
+		log.
+			WithField("sync_from_height", from).
+			WithField("skipped_heights", from-1).
+			Info("skipping heights below the sync start height")
+		blocksRequest.StartHeight = uint(from)
 	}
 
 	log.
@@ -87,10 +96,70 @@ func (w SyncWorker) Run() (int, error) {
 		return 0, nil
 	}
 
+	lastEpoch := -1
+	if lastBlock != nil {
+		lastEpoch = lastBlock.Epoch
+	}
+
+	newBlocks := make([]archive.Block, 0, len(blocks))
+	hashes := make([]string, 0, len(blocks))
+
 	for _, block := range blocks {
-		if err := w.processBlock(block.StateHash); err != nil {
+		if err := w.checkNewBlock(block); err != nil {
+			if err == indexing.ErrAlreadyIndexed {
+				log.WithField("height", block.Height).Debug("skipping already indexed height")
+				continue
+			}
 			return 0, err
 		}
+
+		newBlocks = append(newBlocks, block)
+		hashes = append(hashes, block.StateHash)
+	}
+
+	// Blocks are fetched concurrently and imported in the chain order
+	prepared, err := indexing.ParallelPrepareHashes(ctx, w.archiveClient, w.graphClient, hashes, w.cfg.PrepareWorkers)
+	if err != nil {
+		return 0, err
+	}
+
+	// Token balances are fetched from the node as of now, so only accounts of blocks near the tip are refreshed
+	tokenAccounts := map[string]bool{}
+
+	for idx, data := range prepared {
+		block := newBlocks[idx]
+
+		// The pool size is only meaningful for blocks produced around now
+		if nearTip(status, block.Height) {
+			w.setPendingCount(ctx, data)
+		}
+
+		if err := w.importBlock(ctx, data); err != nil {
+			if errors.Is(err, indexing.ErrImportFailed) {
+				log.WithError(err).WithField("height", block.Height).Error("block recorded as failed")
+				continue
+			}
+			return 0, err
+		}
+
+		if nearTip(status, block.Height) {
+			for _, acc := range data.Accounts {
+				tokenAccounts[acc.PublicKey] = true
+			}
+		}
+
+		epoch := int(block.GlobalSlot) / model.SlotsPerEpoch
+		if lastEpoch >= 0 && epoch > lastEpoch {
+			log.WithField("epoch", epoch).Info("epoch transition detected")
+			if err := indexing.EpochTransitionHandler(w.db, w.graphClient, w.archiveClient, epoch); err != nil {
+				return 0, err
+			}
+		}
+		lastEpoch = epoch
+	}
+
+	if len(tokenAccounts) > 0 {
+		w.importTokenBalances(ctx, tokenAccounts)
 	}
 
 	log.Info("correcting canonical blocks")
@@ -110,9 +179,22 @@ func (w SyncWorker) Run() (int, error) {
 		blocksRequest.Limit = uint(lastBlock.Height)
 	}
 
-	canonicalBlocks, err := w.archiveClient.Blocks(blocksRequest)
-	if err != nil {
-		return 0, err
+	// Heights below the sync start height are never indexed
+	if from := uint(w.cfg.SyncFromHeight); blocksRequest.StartHeight < from {
+		end := blocksRequest.StartHeight + blocksRequest.Limit
+		blocksRequest.StartHeight = from
+		blocksRequest.Limit = 0
+		if end > from {
+			blocksRequest.Limit = end - from
+		}
+	}
+
+	canonicalBlocks := []archive.Block{}
+	if blocksRequest.Limit > 0 {
+		canonicalBlocks, err = w.archiveClient.Blocks(blocksRequest)
+		if err != nil {
+			return 0, err
+		}
 	}
 	for _, block := range canonicalBlocks {
 		_, err := w.db.Blocks.FindByHash(block.StateHash)
@@ -120,8 +202,11 @@ func (w SyncWorker) Run() (int, error) {
 			if err != store.ErrNotFound {
 				return 0, err
 			}
-			if err := w.processBlock(block.StateHash); err != nil {
-				return 0, err
+			if err := w.processBlock(ctx, block.StateHash); err != nil {
+				if !errors.Is(err, indexing.ErrImportFailed) {
+					return 0, err
+				}
+				log.WithError(err).WithField("height", block.Height).Error("block recorded as failed")
 			}
 		}
 
@@ -139,11 +224,17 @@ func (w SyncWorker) Run() (int, error) {
 		}
 	}
 
-	log.Info("correcting canonical blocks and validators statistics")
 	var startingBlock uint64
 	if (int(lastBlock.Height) - int(limit)) > 0 {
 		startingBlock = lastBlock.Height - unsafeBlockThreshold
 	}
+
+	log.Info("detecting orphaned blocks")
+	if _, err := indexing.DetectOrphans(w.db, w.archiveClient, startingBlock); err != nil {
+		return 0, err
+	}
+
+	log.Info("correcting canonical blocks and validators statistics")
 	unsafeBlocks, err := w.db.Blocks.FindUnsafeBlocks(startingBlock)
 	if err != nil {
 		return 0, err
@@ -167,8 +258,19 @@ func (w SyncWorker) Run() (int, error) {
 		}
 	}
 
+	volumeDays := map[string]bool{}
+
 	for _, block := range blockKeys {
 		ts := block.Time
+
+		if day := ts.UTC().Format("2006-01-02"); !volumeDays[day] {
+			log.WithField("day", day).Debug("updating transactions volume")
+			if err := w.db.Stats.CreateDailyTransactionVolume(ts); err != nil {
+				return 0, err
+			}
+			volumeDays[day] = true
+		}
+
 		buckets := []string{store.BucketHour, store.BucketDay}
 
 		for _, bucket := range buckets {
@@ -192,6 +294,10 @@ func (w SyncWorker) Run() (int, error) {
 		// do not abort here
 	}
 
+	if err := w.checkTransactionsLag(); err != nil {
+		log.WithError(err).Error("transactions lag check failed")
+	}
+
 	var lag int
 
 	if len(blocks) > 0 {
@@ -202,41 +308,117 @@ func (w SyncWorker) Run() (int, error) {
 	return lag, err
 }
 
-func (w SyncWorker) processBlock(hash string) error {
-	archiveBlock, err := w.archiveClient.Block(hash)
+// CheckSyncFromHeight stores the configured sync start height on the first run
+// and warns when a later run is configured with a different one
+func CheckSyncFromHeight(db *store.Store, height uint64) error {
+	configured := strconv.FormatUint(height, 10)
+
+	stored, err := db.Metadata.Get(syncFromHeightKey)
+	if err == store.ErrNotFound {
+		return db.Metadata.Set(syncFromHeightKey, configured)
+	}
+	if err != nil {
+		return err
+	}
+
+	if stored != configured {
+		log.
+			WithField("configured", configured).
+			WithField("stored", stored).
+			Warn("sync start height differs from the one used by the existing data, indexed heights are not changed")
+	}
+
+	return nil
+}
+
+// checkTransactionsLag reports blocks indexed without their transactions
+func (w SyncWorker) checkTransactionsLag() error {
+	tipHeight, err := w.db.Blocks.TipHeight()
 	if err != nil {
 		return err
 	}
 
-	graphBlock, err := w.graphClient.GetBlock(hash)
+	txHeight, err := w.db.Transactions.MaxHeight()
 	if err != nil {
-		if !strings.Contains(err.Error(), "not found in transition frontier") {
-			return err
-		}
+		return err
+	}
 
-		log.WithError(err).Debug("graph block error")
-		graphBlock = nil
+	if tipHeight <= txHeight+1 {
+		transactionLagGauge.Set(0)
+		return nil
 	}
 
+	transactionLagGauge.Set(float64(tipHeight - txHeight))
+
 	log.
-		WithField("hash", archiveBlock.StateHash).
-		WithField("height", archiveBlock.Height).
-		Debug("processing block")
+		WithField("mina_indexer_transaction_lag_blocks", tipHeight-txHeight).
+		WithField("tip_height", tipHeight).
+		WithField("transactions_height", txHeight).
+		Warn("transactions are lagging behind blocks")
+
+	return nil
+}
 
-	data, err := indexing.Prepare(archiveBlock, graphBlock)
+// checkNewBlock returns ErrAlreadyIndexed if the block height is already indexed
+func (w SyncWorker) checkNewBlock(block archive.Block) error {
+	indexed, err := indexing.AlreadyIndexed(w.db, block.Height)
 	if err != nil {
 		return err
 	}
+	if indexed {
+		return indexing.ErrAlreadyIndexed
+	}
+	return nil
+}
 
-	if err := indexing.Import(w.db, data); err != nil {
+func (w SyncWorker) processBlock(ctx context.Context, hash string) error {
+	data, err := indexing.PrepareBlock(ctx, w.archiveClient, w.graphClient, hash)
+	if err != nil {
 		return err
 	}
+	return w.importBlock(ctx, data)
+}
 
-	if err := indexing.Finalize(w.db, data); err != nil {
-		return err
+func (w SyncWorker) importBlock(ctx context.Context, data *indexing.Data) error {
+	log.
+		WithField("hash", data.Block.Hash).
+		WithField("height", data.Block.Height).
+		Debug("processing block")
+
+	return indexing.ImportOrRecord(w.db, data)
+}
+
+// setPendingCount records the current transaction pool size with the block
+func (w SyncWorker) setPendingCount(ctx context.Context, data *indexing.Data) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	pendingCount, err := w.graphClient.GetPendingTransactionCount(ctx)
+	if err != nil {
+		log.WithError(err).Warn("pending transactions count fetch failed")
+		return
 	}
+	data.Block.PendingTxCount = &pendingCount
+}
 
-	return nil
+func (w SyncWorker) importTokenBalances(ctx context.Context, accounts map[string]bool) {
+	keys := make([]string, 0, len(accounts))
+	for key := range accounts {
+		keys = append(keys, key)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	log.WithField("count", len(keys)).Debug("importing token balances")
+	if err := indexing.ImportTokenBalances(ctx, w.db, w.graphClient, keys); err != nil {
+		log.WithError(err).Warn("token balances import failed")
+	}
+}
+
+// nearTip returns true if the height is within the unsafe blocks range of the node best tip
+func nearTip(status *graph.DaemonStatus, height uint64) bool {
+	return status.HighestBlockLengthReceived-int(height) <= unsafeBlockThreshold
 }
 
 func (w SyncWorker) checkNodeStatus() (*graph.DaemonStatus, error) {
@@ -262,56 +444,16 @@ func (w SyncWorker) checkNodeStatus() (*graph.DaemonStatus, error) {
 	return status, nil
 }
 
-func (w SyncWorker) processStakingLedger() (*mapper.LedgerData, error) {
+func (w SyncWorker) processStakingLedger() error {
 	tip, err := w.graphClient.ConsensusTip()
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	var epoch int
 	fmt.Sscanf(tip.ProtocolState.ConsensusState.Epoch, "%d", &epoch)
 
-	// Find ledger for current epoch. Ledger only changes once per epoch.
-	currentLedger, err := w.db.Staking.FindLedger(epoch)
-	if err != nil && err != store.ErrNotFound {
-		return nil, err
-	}
-
-	// We already have current epoch ledger, no need to import it.
-	if currentLedger != nil && currentLedger.EntriesCount > 0 {
-		records, err := w.db.Staking.LedgerRecords(currentLedger.ID)
-		if err != nil && err != store.ErrNotFound || len(records) > 0 {
-			return nil, nil
-		}
-	}
-
-	ledger, err := w.archiveClient.StakingLedger(archive.LedgerTypeCurrent)
-	if err != nil {
-		return nil, err
-	}
-
-	ledgerData, err := mapper.Ledger(tip, ledger)
-	if err != nil {
-		return nil, err
-	}
-
-	if currentLedger == nil {
-		err = w.db.Staking.CreateLedger(ledgerData.Ledger)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		ledgerData.Ledger = currentLedger
-	}
-
-	ledgerData.UpdateLedgerID()
-
-	err = w.db.Staking.CreateLedgerEntries(ledgerData.Entries)
-	if err != nil {
-		return nil, err
-	}
-
-	return ledgerData, nil
+	return indexing.EpochTransitionHandler(w.db, w.graphClient, w.archiveClient, epoch)
 }
 
 func (w SyncWorker) processStagingLedger() error {
@@ -329,6 +471,10 @@ func (w SyncWorker) processStagingLedger() error {
 
 	ledger, err := w.archiveClient.StakingLedger(archive.LedgerTypeStaged)
 	if err != nil {
+		if err == archive.ErrNotSupported {
+			log.Debug("staged ledger is not available from archive node")
+			return nil
+		}
 		log.WithError(err).Error("staged ledger fetch failed")
 		return err
 	}